@@ -3,20 +3,28 @@ package main
 import (
 	"context"
 	"errors"
+	"io"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 
 	orderHandler "github.com/avraam311/order-service/backend/internal/api/handlers/order"
 	"github.com/avraam311/order-service/backend/internal/api/server"
 	"github.com/avraam311/order-service/backend/internal/config"
+	"github.com/avraam311/order-service/backend/internal/models"
 	"github.com/avraam311/order-service/backend/internal/pkg/cache"
+	"github.com/avraam311/order-service/backend/internal/pkg/idempotency"
+	"github.com/avraam311/order-service/backend/internal/pkg/kafka"
 	"github.com/avraam311/order-service/backend/internal/pkg/logger"
+	"github.com/avraam311/order-service/backend/internal/pkg/outbox"
+	"github.com/avraam311/order-service/backend/internal/pkg/validator"
 	orderRepo "github.com/avraam311/order-service/backend/internal/repository/order"
 	orderService "github.com/avraam311/order-service/backend/internal/service/order"
 )
@@ -34,17 +42,55 @@ func main() {
 	}
 
 	repo := orderRepo.New(dbpool)
-	cache := cache.New(cfg.Cache.DefaultExpiration, cfg.Cache.CleanupInterval, log, repo)
+	orderCache, redisClient := newOrderCache(cfg.Cache, log)
+
+	snapshotter, hasSnapshot := orderCache.(cacheSnapshotter)
+	hasSnapshot = hasSnapshot && cfg.Cache.Snapshot.Path != ""
+
+	loaded := 0
+	if hasSnapshot {
+		loaded, err = snapshotter.LoadSnapshot(cfg.Cache.Snapshot.Path, cfg.Cache.Snapshot.MaxAge)
+		if err != nil {
+			log.Info("снимок кэша не загружен, загрузка из бд", zap.Error(err))
+		} else {
+			log.Info("кэш загружен из снимка", zap.Int("orders_count", loaded))
+		}
+	}
 
-	err = cache.Preload(ctx, cfg.Cache.PreloadLimit)
-	if err != nil {
-		log.Fatal("ошибка загрузки кэша", zap.Error(err))
+	if loaded == 0 {
+		err = cache.Preload(ctx, orderCache, repo, cfg.Cache.PreloadLimit, log)
+		if err != nil {
+			log.Fatal("ошибка загрузки кэша", zap.Error(err))
+		}
+	}
+
+	orderService := orderService.New(orderCache, repo)
+
+	invalidator := cache.NewInvalidator(cache.DeleteFunc(orderService.InvalidateCache), log)
+	listener := orderRepo.NewListener(dbpool, log)
+	go listener.Listen(ctx, func(e orderRepo.ChangeEvent) {
+		invalidator.Invalidate(e.OrderID, e.ChangedAt)
+	}, orderCache.FlushLocal)
+
+	var outboxWriter io.Closer
+	if cfg.Outbox.Enabled {
+		w := kafka.NewWriter(cfg.Outbox.Topic, cfg.Kafka.Brokers)
+		outboxWriter = w
+		relay := outbox.NewRelay(repo, w, log, cfg.Outbox.Interval, cfg.Outbox.BatchSize, cfg.Outbox.Retention)
+		go relay.Run(ctx)
 	}
 
-	orderService := orderService.New(cache, repo)
 	orderGetHandler := orderHandler.NewGetHandler(log, orderService)
+	orderListHandler := orderHandler.NewListHandler(log, orderService)
+	idempotencyStore := idempotency.NewStore(cfg.Server.IdempotencyTTL, cfg.Server.IdempotencyMaxKeys)
+	if cfg.Server.IdempotencySweepInterval > 0 {
+		go idempotencyStore.Run(ctx, cfg.Server.IdempotencySweepInterval)
+	}
+
+	orderCreateHandler := orderHandler.NewCreateHandler(log, validator.New(), orderService, idempotencyStore)
+	orderStatusHandler := orderHandler.NewStatusHandler(log, orderService)
 
-	r := server.NewRouter(orderGetHandler)
+	r := server.NewRouter(orderGetHandler, orderListHandler, orderCreateHandler, orderStatusHandler)
 	server := server.NewServer(cfg.Server.HTTPPort, r)
 
 	go func() {
@@ -70,6 +116,88 @@ func main() {
 		log.Fatal("время ожидания превышено, принудительное закрытие")
 	}
 
+	stats := invalidator.Stats()
+	log.Info("синхронизация кэша",
+		zap.Int64("invalidations", stats.Count),
+		zap.Duration("avg_latency", stats.Avg),
+		zap.Duration("max_latency", stats.Max),
+	)
+
+	if hasSnapshot {
+		if err = snapshotter.SaveSnapshot(cfg.Cache.Snapshot.Path); err != nil {
+			log.Error("ошибка сохранения снимка кэша", zap.Error(err))
+		} else {
+			log.Info("снимок кэша сохранен", zap.String("path", cfg.Cache.Snapshot.Path))
+		}
+	}
+
+	if c, ok := orderCache.(interface{ Stats() cache.Stats }); ok {
+		cacheStats := c.Stats()
+		log.Info("статистика кэша",
+			zap.Int("entries", cacheStats.Entries),
+			zap.Int64("bytes", cacheStats.Bytes),
+			zap.Int64("hits", cacheStats.Hits),
+			zap.Int64("negative_hits", cacheStats.NegativeHits),
+			zap.Int64("misses", cacheStats.Misses),
+			zap.Int64("evictions", cacheStats.Evictions),
+			zap.Int64("expirations", cacheStats.Expirations),
+		)
+	}
+
+	if redisClient != nil {
+		log.Info("закрытие соединения с redis")
+		if err = redisClient.Close(); err != nil {
+			log.Error("ошибка при закрытии соединения с redis", zap.Error(err))
+		}
+	}
+
+	if outboxWriter != nil {
+		log.Info("закрытие writer outbox")
+		if err = outboxWriter.Close(); err != nil {
+			log.Error("ошибка при закрытии writer outbox", zap.Error(err))
+		}
+	}
+
 	log.Info("закрытие пула соединений бд")
 	dbpool.Close()
 }
+
+type orderCache interface {
+	Get(orderID uuid.UUID) (*models.Order, bool)
+	Set(orderID uuid.UUID, order *models.Order)
+	SetNotFound(orderID uuid.UUID)
+	Delete(orderID uuid.UUID)
+	FlushLocal()
+}
+
+type cacheSnapshotter interface {
+	SaveSnapshot(path string) error
+	LoadSnapshot(path string, maxAge time.Duration) (int, error)
+}
+
+func newOrderCache(cfg config.Cache, log *zap.Logger) (orderCache, *redis.Client) {
+	local := func() *cache.LRU {
+		return cache.New(cfg.MaxEntries, cfg.MaxBytes, cfg.DefaultExpiration, cfg.NegativeTTL)
+	}
+
+	if cfg.Backend == cache.BackendMemory || cfg.Backend == "" {
+		return local(), nil
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     cfg.Redis.Addr,
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.DB,
+	})
+	shared := cache.NewRedis(client, cfg.Redis.KeyPrefix, cfg.DefaultExpiration, cfg.NegativeTTL, log)
+
+	switch cfg.Backend {
+	case cache.BackendRedis:
+		return shared, client
+	case cache.BackendTiered:
+		return cache.NewTiered(local(), shared), client
+	default:
+		log.Fatal("неизвестный тип кэша", zap.String("backend", cfg.Backend))
+		return nil, nil
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"os/signal"
+	"slices"
 	"sync"
 	"syscall"
 
@@ -11,6 +12,8 @@ import (
 
 	"github.com/avraam311/order-service/backend/internal/config"
 	"github.com/avraam311/order-service/backend/internal/pkg/kafka"
+	"github.com/avraam311/order-service/backend/internal/pkg/kafka/codec"
+	"github.com/avraam311/order-service/backend/internal/pkg/kafka/envelope"
 	"github.com/avraam311/order-service/backend/internal/pkg/kafka/handlers"
 	"github.com/avraam311/order-service/backend/internal/pkg/logger"
 	"github.com/avraam311/order-service/backend/internal/pkg/validator"
@@ -37,12 +40,101 @@ func main() {
 	orderService := orderService.New(nil, repo)
 	val := validator.New()
 
-	orderCreatedHandler := handlers.NewCreateHandler(val, orderService)
+	codecs := []codec.Codec{codec.JSON{}, codec.Protobuf{}}
+	if cfg.Kafka.SchemaRegistryDir != "" {
+		registry := codec.NewFileRegistry(cfg.Kafka.SchemaRegistryDir)
+		schemaID, err := registry.Register(codec.OrderSchema)
+		if err != nil {
+			log.Fatal("backend/cmd/consumer/main.go, ошибка регистрации avro схемы", zap.Error(err))
+		}
+		codecs = append(codecs, codec.NewAvro(registry, schemaID))
+		log.Info("avro включен", zap.Int("schema_id", schemaID))
+	}
+
+	dlqs := make(map[string]*kafka.DeadLetterQueue)
+	dlqFor := func(c config.DLQ) *kafka.DeadLetterQueue {
+		if !c.Enabled {
+			return nil
+		}
+		if dlq, ok := dlqs[c.Topic]; ok {
+			return dlq
+		}
+
+		dlq := kafka.NewDeadLetterQueue(kafka.NewWriter(c.Topic, cfg.Kafka.Brokers))
+		dlqs[c.Topic] = dlq
+		log.Info("dlq включен", zap.String("topic", c.Topic))
+		return dlq
+	}
+
+	routes := cfg.Kafka.Routes
+	if len(routes) == 0 {
+		routes = []config.Route{{Topic: cfg.Kafka.Topic, Handler: envelope.EventOrderCreated}}
+	}
+
+	var topics []string
+	kafkaRoutes := make([]kafka.Route, 0, len(routes))
+	for _, rc := range routes {
+		if rc.Topic == "" {
+			rc.Topic = cfg.Kafka.Topic
+		}
+
+		route := kafka.Route{
+			Topic:     rc.Topic,
+			EventType: rc.EventType,
+			Retry:     retryPolicy(cfg.Kafka.Retry),
+			DLQ:       dlqFor(cfg.Kafka.DLQ),
+		}
+		if rc.Retry != nil {
+			route.Retry = retryPolicy(*rc.Retry)
+		}
+		if rc.DLQ != nil {
+			route.DLQ = dlqFor(*rc.DLQ)
+		}
 
-	reader := kafka.NewReader(cfg.Kafka.GroupID, cfg.Kafka.Topic, cfg.Kafka.Brokers)
-	consumer := kafka.NewConsumer(reader, log, orderCreatedHandler)
+		switch rc.Handler {
+		case envelope.EventOrderCreated:
+			route.Handler = handlers.NewCreateHandler(val, codec.NewSet(codecs...), orderService)
+		case envelope.EventOrderStatusChanged:
+			route.Handler = handlers.NewStatusChangedHandler(val, orderService)
+		case envelope.EventOrderCancelled:
+			route.Handler = handlers.NewCancelledHandler(val, orderService)
+		case envelope.EventPaymentUpdated:
+			route.Handler = handlers.NewPaymentHandler(val, orderService)
+		default:
+			log.Fatal("backend/cmd/consumer/main.go, неизвестный обработчик", zap.String("handler", rc.Handler))
+		}
+
+		if !slices.Contains(topics, rc.Topic) {
+			topics = append(topics, rc.Topic)
+		}
+		kafkaRoutes = append(kafkaRoutes, route)
+		log.Info("маршрут",
+			zap.String("topic", rc.Topic),
+			zap.String("event_type", rc.EventType),
+			zap.String("handler", rc.Handler),
+		)
+	}
+
+	reader := kafka.NewReader(cfg.Kafka.GroupID, topics, cfg.Kafka.Brokers)
+	consumer := kafka.NewRoutedConsumer(reader, log, kafka.NewRouter(dlqFor(cfg.Kafka.DLQ), kafkaRoutes...))
 	wg.Add(1)
-	go consumer.ConsumeMessage(ctx, &wg)
+	go func() {
+		switch {
+		case cfg.Kafka.Batch.Enabled:
+			consumer.ConsumeBatches(ctx, &wg, kafka.BatchPolicy{
+				Size:   cfg.Kafka.Batch.Size,
+				Linger: cfg.Kafka.Batch.Linger,
+			})
+		case cfg.Kafka.Workers.Concurrency > 1:
+			consumer.ConsumeConcurrent(ctx, &wg, kafka.PoolPolicy{
+				Workers: cfg.Kafka.Workers.Concurrency,
+				OrderBy: cfg.Kafka.Workers.OrderBy,
+			})
+		default:
+			consumer.ConsumeMessage(ctx, &wg)
+		}
+		stop()
+	}()
 
 	log.Info("кафка консьюмер запущен")
 
@@ -57,6 +149,21 @@ func main() {
 		log.Error("backend/cmd/consumer/main.go, ошибка при закрытии консьюмера кафки: %v", zap.Error(err))
 	}
 
+	for topic, dlq := range dlqs {
+		log.Info("закрытие продюсера dlq", zap.String("topic", topic))
+		if err = dlq.Close(); err != nil {
+			log.Error("backend/cmd/consumer/main.go, ошибка при закрытии продюсера dlq", zap.Error(err))
+		}
+	}
+
 	log.Info("закрытие пула соединений бд")
 	dbpool.Close()
 }
+
+func retryPolicy(r config.Retry) kafka.RetryPolicy {
+	return kafka.RetryPolicy{
+		MaxAttempts: r.MaxAttempts,
+		BaseDelay:   r.BaseDelay,
+		MaxDelay:    r.MaxDelay,
+	}
+}
@@ -0,0 +1,302 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+
+	"github.com/avraam311/order-service/backend/internal/pkg/generator"
+	kafkapkg "github.com/avraam311/order-service/backend/internal/pkg/kafka"
+	"github.com/avraam311/order-service/backend/internal/pkg/kafka/codec"
+	"github.com/avraam311/order-service/backend/internal/pkg/kafka/envelope"
+)
+
+const producerID = "order-generator"
+
+type sink interface {
+	Write(ctx context.Context, m kafka.Message) error
+	Close() error
+	// Failed возвращает число сообщений, которые не удалось записать.
+	Failed() int64
+}
+
+func main() {
+	brokers := flag.String("brokers", "kafka:9092", "адреса брокеров через запятую")
+	topic := flag.String("topic", "order-service", "топик для заказов")
+	out := flag.String("out", "", "писать NDJSON в файл вместо kafka (\"-\" — stdout)")
+	format := flag.String("format", "json", "формат сообщений: json, protobuf или avro")
+	schemaDir := flag.String("schema-registry", "", "каталог файлового реестра avro схем")
+	count := flag.Int("n", 1000, "сколько сообщений отправить, 0 — до остановки")
+	rate := flag.Float64("rate", 100, "сообщений в секунду, 0 — без ограничения")
+	items := flag.String("items", "uniform:1-5", "распределение числа товаров: fixed:N, uniform:MIN-MAX или poisson:MEAN")
+	locale := flag.String("locale", "en", "локаль заказов: en или ru")
+	currency := flag.String("currency", "USD", "валюта оплаты")
+	invalid := flag.Float64("invalid", 0, "доля заказов, не проходящих валидацию")
+	malformed := flag.Float64("malformed", 0, "доля испорченных сообщений")
+	duplicate := flag.Float64("duplicate", 0, "доля повторно отправленных заказов")
+	seed := flag.Uint64("seed", 0, "seed генератора, 0 — случайный")
+	flag.Parse()
+
+	log, err := zap.NewDevelopment()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ошибка создания логгера: %v\n", err)
+		os.Exit(1)
+	}
+	defer log.Sync()
+
+	if *count < 0 {
+		log.Fatal("backend/cmd/producer/main.go, число сообщений не может быть отрицательным", zap.Int("n", *count))
+	}
+
+	// Интервал тикера должен быть не меньше наносекунды.
+	if !(*rate >= 0 && *rate <= float64(time.Second)) {
+		log.Fatal("backend/cmd/producer/main.go, скорость должна быть от 0 до 1e9 сообщений в секунду", zap.Float64("rate", *rate))
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	dist, err := generator.ParseDistribution(*items)
+	if err != nil {
+		log.Fatal("backend/cmd/producer/main.go, ошибка в распределении товаров", zap.Error(err))
+	}
+
+	if *seed == 0 {
+		*seed = uint64(time.Now().UnixNano())
+	}
+
+	gen, err := generator.New(generator.Config{
+		Items:         dist,
+		Locale:        *locale,
+		Currency:      *currency,
+		InvalidRate:   *invalid,
+		MalformedRate: *malformed,
+		DuplicateRate: *duplicate,
+	}, *seed)
+	if err != nil {
+		log.Fatal("backend/cmd/producer/main.go, ошибка в параметрах генератора", zap.Error(err))
+	}
+
+	c, err := newCodec(*format, *schemaDir)
+	if err != nil {
+		log.Fatal("backend/cmd/producer/main.go, ошибка создания кодека", zap.Error(err))
+	}
+
+	var s sink
+	if *out != "" {
+		if c.ContentType() != codec.ContentTypeJSON {
+			log.Fatal("backend/cmd/producer/main.go, NDJSON поддерживает только формат json", zap.String("format", *format))
+		}
+		s, err = newFileSink(*out)
+		if err != nil {
+			log.Fatal("backend/cmd/producer/main.go, ошибка открытия файла", zap.Error(err))
+		}
+	} else {
+		s = newKafkaSink(*topic, strings.Split(*brokers, ","), log)
+	}
+
+	log.Info("запуск генератора заказов",
+		zap.Int("count", *count),
+		zap.Float64("rate", *rate),
+		zap.String("items", dist.String()),
+		zap.String("format", c.ContentType()),
+		zap.Uint64("seed", *seed),
+	)
+
+	started := time.Now()
+	counts := produce(ctx, gen, c, s, *count, *rate, log)
+
+	closeErr := s.Close()
+	if closeErr != nil {
+		log.Error("ошибка при закрытии вывода", zap.Error(closeErr))
+	}
+
+	total := 0
+	for _, n := range counts {
+		total += n
+	}
+	elapsed := time.Since(started)
+
+	fields := []zap.Field{
+		zap.Int("total", total),
+		zap.Duration("elapsed", elapsed),
+		zap.Float64("rate", float64(total)/elapsed.Seconds()),
+	}
+	for _, kind := range []generator.Kind{generator.KindValid, generator.KindInvalid, generator.KindMalformed, generator.KindDuplicate} {
+		fields = append(fields, zap.Int(string(kind), counts[kind]))
+	}
+	failed := s.Failed()
+	fields = append(fields, zap.Int64("failed", failed))
+	log.Info("генерация завершена", fields...)
+
+	if failed > 0 || closeErr != nil {
+		log.Error("часть сообщений не записана", zap.Int64("failed", failed), zap.Error(closeErr))
+		log.Sync()
+		os.Exit(1)
+	}
+}
+
+func produce(ctx context.Context, gen *generator.Generator, c codec.Codec, s sink, count int, rate float64, log *zap.Logger) map[generator.Kind]int {
+	counts := make(map[generator.Kind]int)
+
+	var tick <-chan time.Time
+	if rate > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / rate))
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for i := 0; count == 0 || i < count; i++ {
+		if tick != nil {
+			select {
+			case <-ctx.Done():
+				return counts
+			case <-tick:
+			}
+		} else if ctx.Err() != nil {
+			return counts
+		}
+
+		sample := gen.Next()
+		value, err := c.Encode(producerID, sample.Order)
+		if err != nil {
+			log.Error("ошибка кодирования заказа", zap.Error(err))
+			continue
+		}
+		if sample.Kind == generator.KindMalformed {
+			value = generator.Corrupt(value)
+		}
+
+		err = s.Write(ctx, kafka.Message{
+			Key:   []byte(sample.Order.OrderID.String()),
+			Value: value,
+			Headers: []kafka.Header{
+				{Key: kafkapkg.EventTypeHeader, Value: []byte(envelope.EventOrderCreated)},
+				{Key: codec.ContentTypeHeader, Value: []byte(c.ContentType())},
+			},
+		})
+		if err != nil {
+			log.Error("ошибка отправки сообщения", zap.Error(err))
+			return counts
+		}
+
+		counts[sample.Kind]++
+	}
+
+	return counts
+}
+
+func newCodec(format, schemaDir string) (codec.Codec, error) {
+	switch format {
+	case "json":
+		return codec.JSON{}, nil
+	case "protobuf":
+		return codec.Protobuf{}, nil
+	case "avro":
+		if schemaDir == "" {
+			return nil, fmt.Errorf("для avro нужен -schema-registry")
+		}
+		registry := codec.NewFileRegistry(schemaDir)
+		schemaID, err := registry.Register(codec.OrderSchema)
+		if err != nil {
+			return nil, err
+		}
+		return codec.NewAvro(registry, schemaID), nil
+	default:
+		return nil, fmt.Errorf("неизвестный формат %q", format)
+	}
+}
+
+// kafkaSink пишет асинхронно, чтобы скорость генерации не упиралась в
+// ожидание подтверждений. Ошибки доставки считаются и проверяются в конце.
+type kafkaSink struct {
+	writer *kafka.Writer
+	failed atomic.Int64
+}
+
+func newKafkaSink(topic string, brokers []string, log *zap.Logger) *kafkaSink {
+	s := &kafkaSink{writer: kafkapkg.NewWriter(topic, brokers)}
+	s.writer.Async = true
+	s.writer.BatchTimeout = 50 * time.Millisecond
+	s.writer.Completion = func(msgs []kafka.Message, err error) {
+		if err != nil {
+			s.failed.Add(int64(len(msgs)))
+			log.Warn("ошибка доставки сообщений", zap.Int("messages_count", len(msgs)), zap.Error(err))
+		}
+	}
+
+	return s
+}
+
+func (s *kafkaSink) Write(ctx context.Context, m kafka.Message) error {
+	if err := s.writer.WriteMessages(ctx, m); err != nil {
+		s.failed.Add(1)
+		return err
+	}
+
+	return nil
+}
+
+func (s *kafkaSink) Close() error {
+	return s.writer.Close()
+}
+
+func (s *kafkaSink) Failed() int64 {
+	return s.failed.Load()
+}
+
+type fileSink struct {
+	file   io.WriteCloser
+	buf    *bufio.Writer
+	failed int64
+}
+
+func newFileSink(path string) (*fileSink, error) {
+	var f io.WriteCloser = os.Stdout
+	if path != "-" {
+		var err error
+		if f, err = os.Create(path); err != nil {
+			return nil, err
+		}
+	}
+
+	return &fileSink{file: f, buf: bufio.NewWriter(f)}, nil
+}
+
+func (s *fileSink) Write(_ context.Context, m kafka.Message) error {
+	_, err := s.buf.Write(m.Value)
+	if err == nil {
+		err = s.buf.WriteByte('\n')
+	}
+	if err != nil {
+		s.failed++
+	}
+
+	return err
+}
+
+func (s *fileSink) Failed() int64 {
+	return s.failed
+}
+
+func (s *fileSink) Close() error {
+	if err := s.buf.Flush(); err != nil {
+		return err
+	}
+	if s.file == os.Stdout {
+		return nil
+	}
+
+	return s.file.Close()
+}
@@ -0,0 +1,133 @@
+package order
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+	"github.com/avraam311/order-service/backend/internal/pkg/idempotency"
+	goValidator "github.com/avraam311/order-service/backend/internal/pkg/validator"
+)
+
+const (
+	idempotencyKeyHeader = "Idempotency-Key"
+	maxOrderBodySize     = 1 << 20
+)
+
+type orderCreator interface {
+	SaveOrder(ctx context.Context, order *models.Order) (uuid.UUID, error)
+}
+
+type validator interface {
+	Validate(i interface{}) error
+}
+
+type idempotencyStore interface {
+	Begin(key, fingerprint string) (*idempotency.Response, error)
+	Complete(key string, resp idempotency.Response)
+	Abort(key string)
+}
+
+type CreateHandler struct {
+	logger       *zap.Logger
+	validator    validator
+	orderService orderCreator
+	idempotency  idempotencyStore
+}
+
+type createdResponse struct {
+	OrderID uuid.UUID `json:"order_uid"`
+}
+
+type validationErrorResponse struct {
+	Error  string                   `json:"error"`
+	Fields []goValidator.FieldError `json:"fields"`
+}
+
+func NewCreateHandler(l *zap.Logger, v validator, s orderCreator, store idempotencyStore) *CreateHandler {
+	return &CreateHandler{
+		logger:       l,
+		validator:    v,
+		orderService: s,
+		idempotency:  store,
+	}
+}
+
+func (h *CreateHandler) CreateOrder(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxOrderBodySize))
+	if err != nil {
+		http.Error(w, "не удалось прочитать тело запроса", http.StatusBadRequest)
+		return
+	}
+
+	key := r.Header.Get(idempotencyKeyHeader)
+	if key != "" {
+		sum := sha256.Sum256(body)
+		replay, err := h.idempotency.Begin(key, hex.EncodeToString(sum[:]))
+		if err != nil {
+			writeError(w, h.logger, err)
+			return
+		}
+
+		if replay != nil {
+			w.Header().Set("Idempotent-Replayed", "true")
+			writeResponse(w, *replay)
+			return
+		}
+	}
+
+	resp := h.createOrder(r.Context(), body)
+
+	if key != "" {
+		if resp.Status >= http.StatusInternalServerError {
+			h.idempotency.Abort(key)
+		} else {
+			h.idempotency.Complete(key, resp)
+		}
+	}
+
+	writeResponse(w, resp)
+}
+
+func (h *CreateHandler) createOrder(ctx context.Context, body []byte) idempotency.Response {
+	var order *models.Order
+	if err := json.Unmarshal(body, &order); err != nil {
+		return errorResponse(h.logger, fmt.Errorf("%w: %w", apperrors.ErrInvalidJSON, err))
+	}
+
+	if order == nil {
+		return errorResponse(h.logger, apperrors.ErrEmptyOrder)
+	}
+
+	if err := h.validator.Validate(order); err != nil {
+		fields := goValidator.FieldErrors(err)
+		if fields == nil {
+			return errorResponse(h.logger, fmt.Errorf("%w: %w", apperrors.ErrValidation, err))
+		}
+
+		return jsonResponse(http.StatusUnprocessableEntity, validationErrorResponse{
+			Error:  apperrors.ErrValidation.Message,
+			Fields: fields,
+		})
+	}
+
+	orderID, err := h.orderService.SaveOrder(ctx, order)
+	if err != nil {
+		return errorResponse(h.logger, fmt.Errorf("backend/internal/api/handlers/order/create_handler.go, ошибка создания заказа: %w", err))
+	}
+	h.logger.Info("заказ создан через http", zap.String("order_uid", orderID.String()))
+
+	resp := jsonResponse(http.StatusCreated, createdResponse{OrderID: orderID})
+	resp.Location = "/orders/" + orderID.String()
+
+	return resp
+}
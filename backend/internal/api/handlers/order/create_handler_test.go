@@ -0,0 +1,158 @@
+package order
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	mock_service "github.com/avraam311/order-service/backend/internal/mocks/service"
+	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/models/modeltest"
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+	"github.com/avraam311/order-service/backend/internal/pkg/idempotency"
+	goValidator "github.com/avraam311/order-service/backend/internal/pkg/validator"
+)
+
+func validOrder(t *testing.T, orderID uuid.UUID) models.Order {
+	o := modeltest.Order(t)
+	o.OrderID = orderID
+	o.Payment.Transaction = orderID.String()
+
+	return *o
+}
+
+func TestCreateHandler_CreateOrder(t *testing.T) {
+	orderID := uuid.New()
+
+	body, err := json.Marshal(validOrder(t, orderID))
+	require.NoError(t, err)
+
+	invalid := validOrder(t, orderID)
+	invalid.Delivery.Email = "not-an-email"
+	invalid.Items[0].Price = 0
+	invalidBody, err := json.Marshal(invalid)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name                 string
+		body                 string
+		saveErr              error
+		expectSave           bool
+		expectedStatus       int
+		expectedLocation     string
+		expectedBodyContains []string
+	}{
+		{
+			name:                 "заказ создан",
+			body:                 string(body),
+			expectSave:           true,
+			expectedStatus:       http.StatusCreated,
+			expectedLocation:     "/orders/" + orderID.String(),
+			expectedBodyContains: []string{orderID.String()},
+		},
+		{
+			name:           "неправильный json",
+			body:           `{"order_uid":`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "пустой заказ",
+			body:           `null`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:                 "ошибки валидации по полям",
+			body:                 string(invalidBody),
+			expectedStatus:       http.StatusUnprocessableEntity,
+			expectedBodyContains: []string{`"field":"delivery.email"`, `"field":"items[0].price"`},
+		},
+		{
+			name:           "повтор с теми же данными",
+			body:           string(body),
+			expectSave:     true,
+			saveErr:        fmt.Errorf("repo: %w", apperrors.ErrOrderDuplicate),
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "тот же order_uid с другими данными",
+			body:           string(body),
+			expectSave:     true,
+			saveErr:        fmt.Errorf("repo: %w", apperrors.ErrOrderConflict),
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "ошибка бд",
+			body:           string(body),
+			expectSave:     true,
+			saveErr:        fmt.Errorf("repo: %w: %w", apperrors.ErrInsertOrder, apperrors.ErrDBConnection),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			svc := mock_service.NewMockorderCreator(ctrl)
+			if tt.expectSave {
+				svc.EXPECT().SaveOrder(gomock.Any(), gomock.Any()).Return(orderID, tt.saveErr)
+			}
+
+			h := NewCreateHandler(zaptest.NewLogger(t), goValidator.New(), svc, idempotency.NewStore(time.Hour, 0))
+
+			w := httptest.NewRecorder()
+			h.CreateOrder(w, httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(tt.body)))
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedLocation, w.Header().Get("Location"))
+			for _, s := range tt.expectedBodyContains {
+				assert.Contains(t, w.Body.String(), s)
+			}
+		})
+	}
+}
+
+func TestCreateHandler_IdempotencyKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderID := uuid.New()
+	body, err := json.Marshal(validOrder(t, orderID))
+	require.NoError(t, err)
+
+	svc := mock_service.NewMockorderCreator(ctrl)
+	svc.EXPECT().SaveOrder(gomock.Any(), gomock.Any()).Return(orderID, nil).Times(1)
+
+	h := NewCreateHandler(zaptest.NewLogger(t), goValidator.New(), svc, idempotency.NewStore(time.Hour, 0))
+
+	send := func(key, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(body))
+		r.Header.Set(idempotencyKeyHeader, key)
+		w := httptest.NewRecorder()
+		h.CreateOrder(w, r)
+		return w
+	}
+
+	first := send("key-1", string(body))
+	assert.Equal(t, http.StatusCreated, first.Code)
+
+	replay := send("key-1", string(body))
+	assert.Equal(t, http.StatusCreated, replay.Code)
+	assert.Equal(t, "true", replay.Header().Get("Idempotent-Replayed"))
+	assert.Equal(t, first.Header().Get("Location"), replay.Header().Get("Location"))
+	assert.Equal(t, first.Body.String(), replay.Body.String())
+
+	reused := send("key-1", `{"order_uid":"`+uuid.NewString()+`"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, reused.Code)
+}
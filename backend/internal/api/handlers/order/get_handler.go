@@ -3,7 +3,7 @@ package order
 import (
 	"context"
 	"encoding/json"
-	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/google/uuid"
@@ -14,13 +14,6 @@ import (
 	"github.com/avraam311/order-service/backend/internal/models"
 )
 
-var (
-	ErrOrderNotFound     = errors.New("заказ не найден")
-	ErrScanRow           = errors.New("ошибка сканирования строки")
-	ErrItemScanFailed    = errors.New("ошибка сканирования items заказа")
-	ErrGetItemsByOrderId = errors.New("ошибка получения items по orderID")
-)
-
 type orderService interface {
 	GetOrderByID(ctx context.Context, orderID uuid.UUID) (*models.Order, error)
 }
@@ -52,20 +45,7 @@ func (h *GetHandler) GetOrderByID(w http.ResponseWriter, r *http.Request) {
 
 	order, err := h.orderService.GetOrderByID(r.Context(), orderID)
 	if err != nil {
-		switch {
-		case errors.Is(err, ErrOrderNotFound):
-			http.Error(w, "заказ не найден", http.StatusNotFound)
-		case errors.Is(err, ErrItemScanFailed):
-			http.Error(w, "ошибка сканирования items заказа", http.StatusNotFound)
-		case errors.Is(err, ErrGetItemsByOrderId):
-			http.Error(w, "ошибка получения items по orderID", http.StatusNotFound)
-		case errors.Is(err, ErrScanRow):
-			http.Error(w, "ошибка сканирования строки", http.StatusInternalServerError)
-		default:
-			h.logger.Error("backend/internal/api/handlers/order/get_handler.go, ошибка получения заказа", zap.Error(err))
-			http.Error(w, "ошибка сервера", http.StatusInternalServerError)
-		}
-
+		writeError(w, h.logger, fmt.Errorf("backend/internal/api/handlers/order/get_handler.go, ошибка получения заказа: %w", err))
 		return
 	}
 
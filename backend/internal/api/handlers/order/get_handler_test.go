@@ -3,6 +3,7 @@ package order
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -14,6 +15,7 @@ import (
 
 	mock_service "github.com/avraam311/order-service/backend/internal/mocks/service"
 	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
 
 	"go.uber.org/zap/zaptest"
 )
@@ -67,10 +69,21 @@ func TestGetHandler_GetOrderByID(t *testing.T) {
 			url:  "/order/" + orderID.String(),
 			setupMock: func(ctrl *gomock.Controller) testOrderService {
 				m := mock_service.NewMockorderService(ctrl)
-				m.EXPECT().GetOrderByID(gomock.Any(), orderID).Return(nil, ErrOrderNotFound)
+				m.EXPECT().GetOrderByID(gomock.Any(), orderID).Return(nil, fmt.Errorf("repo: %w", apperrors.ErrOrderNotFound))
 				return m
 			},
-			expectedStatus: http.StatusNotFound,
+			expectedStatus:       http.StatusNotFound,
+			expectedBodyContains: "заказ не найден",
+		},
+		{
+			name: "временная ошибка бд",
+			url:  "/order/" + orderID.String(),
+			setupMock: func(ctrl *gomock.Controller) testOrderService {
+				m := mock_service.NewMockorderService(ctrl)
+				m.EXPECT().GetOrderByID(gomock.Any(), orderID).Return(nil, fmt.Errorf("repo: %w: %w", apperrors.ErrScanRow, apperrors.ErrDBConnection))
+				return m
+			},
+			expectedStatus: http.StatusInternalServerError,
 		},
 		{
 			name: "ошибка сервера",
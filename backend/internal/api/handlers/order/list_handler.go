@@ -0,0 +1,188 @@
+package order
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+)
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+)
+
+type orderLister interface {
+	ListOrders(ctx context.Context, f models.OrderFilter) (*models.OrderPage, error)
+	GetOrdersByIDs(ctx context.Context, orderIDs []uuid.UUID) ([]models.Order, error)
+}
+
+type ordersByIDsResponse struct {
+	Orders   []models.Order `json:"orders"`
+	NotFound []uuid.UUID    `json:"not_found,omitempty"`
+}
+
+type ListHandler struct {
+	logger       *zap.Logger
+	orderService orderLister
+}
+
+func NewListHandler(l *zap.Logger, s orderLister) *ListHandler {
+	return &ListHandler{
+		logger:       l,
+		orderService: s,
+	}
+}
+
+func (h *ListHandler) ListOrders(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Has("ids") {
+		h.getOrdersByIDs(w, r)
+		return
+	}
+
+	filter, err := parseOrderFilter(r.URL.Query())
+	if err != nil {
+		writeError(w, h.logger, err)
+		return
+	}
+
+	page, err := h.orderService.ListOrders(r.Context(), filter)
+	if err != nil {
+		writeError(w, h.logger, fmt.Errorf("backend/internal/api/handlers/order/list_handler.go, ошибка получения списка заказов: %w", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err = json.NewEncoder(w).Encode(page); err != nil {
+		h.logger.Error("backend/internal/api/handlers/order/list_handler.go, ошибка кодирования ответа для списка заказов", zap.Error(err))
+	}
+}
+
+func (h *ListHandler) getOrdersByIDs(w http.ResponseWriter, r *http.Request) {
+	ids, err := parseOrderIDs(r.URL.Query().Get("ids"))
+	if err != nil {
+		writeError(w, h.logger, err)
+		return
+	}
+
+	orders, err := h.orderService.GetOrdersByIDs(r.Context(), ids)
+	if err != nil {
+		writeError(w, h.logger, fmt.Errorf("backend/internal/api/handlers/order/list_handler.go, ошибка получения заказов по id: %w", err))
+		return
+	}
+
+	resp := ordersByIDsResponse{Orders: orders}
+	returned := make(map[uuid.UUID]struct{}, len(orders))
+	for _, o := range orders {
+		returned[o.OrderID] = struct{}{}
+	}
+	for _, id := range ids {
+		if _, ok := returned[id]; !ok {
+			resp.NotFound = append(resp.NotFound, id)
+		}
+	}
+
+	writeResponse(w, jsonResponse(http.StatusOK, resp))
+}
+
+func parseOrderIDs(v string) ([]uuid.UUID, error) {
+	parts := strings.Split(v, ",")
+	ids := make([]uuid.UUID, 0, len(parts))
+	seen := make(map[uuid.UUID]struct{}, len(parts))
+	for _, p := range parts {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+
+		id, err := uuid.Parse(p)
+		if err != nil || id == uuid.Nil {
+			return nil, fmt.Errorf("ids=%q: %w", p, apperrors.ErrInvalidQuery)
+		}
+
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+
+	if len(ids) == 0 || len(ids) > maxListLimit {
+		return nil, fmt.Errorf("ids: нужно от 1 до %d id: %w", maxListLimit, apperrors.ErrInvalidQuery)
+	}
+
+	return ids, nil
+}
+
+func parseOrderFilter(q url.Values) (models.OrderFilter, error) {
+	f := models.OrderFilter{
+		CustomerID:      q.Get("customer_id"),
+		TrackNumber:     q.Get("track_number"),
+		DeliveryService: q.Get("delivery_service"),
+		Provider:        q.Get("provider"),
+		Currency:        q.Get("currency"),
+		Locale:          q.Get("locale"),
+		Cursor:          q.Get("cursor"),
+		SortBy:          models.SortByDateCreated,
+		Limit:           defaultListLimit,
+	}
+
+	switch sortBy := models.OrderSortField(q.Get("sort")); sortBy {
+	case "":
+	case models.SortByDateCreated, models.SortByAmount:
+		f.SortBy = sortBy
+	default:
+		return f, fmt.Errorf("sort=%q: %w", sortBy, apperrors.ErrInvalidQuery)
+	}
+
+	switch order := q.Get("order"); order {
+	case "", "desc":
+	case "asc":
+		f.Ascending = true
+	default:
+		return f, fmt.Errorf("order=%q: %w", order, apperrors.ErrInvalidQuery)
+	}
+
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > maxListLimit {
+			return f, fmt.Errorf("limit=%q: %w", v, apperrors.ErrInvalidQuery)
+		}
+		f.Limit = limit
+	}
+
+	var err error
+	if f.CreatedFrom, err = parseTimeParam(q, "created_from"); err != nil {
+		return f, err
+	}
+	if f.CreatedTo, err = parseTimeParam(q, "created_to"); err != nil {
+		return f, err
+	}
+
+	return f, nil
+}
+
+func parseTimeParam(q url.Values, name string) (*time.Time, error) {
+	v := q.Get(name)
+	if v == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return nil, fmt.Errorf("%s=%q: %w", name, v, apperrors.ErrInvalidQuery)
+	}
+
+	return &t, nil
+}
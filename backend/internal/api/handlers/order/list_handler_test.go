@@ -0,0 +1,186 @@
+package order
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zaptest"
+
+	mock_service "github.com/avraam311/order-service/backend/internal/mocks/service"
+	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+)
+
+func TestListHandler_ListOrders(t *testing.T) {
+	from := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name                 string
+		url                  string
+		wantFilter           *models.OrderFilter
+		serviceErr           error
+		page                 *models.OrderPage
+		expectedStatus       int
+		expectedBodyContains string
+	}{
+		{
+			name: "значения по умолчанию",
+			url:  "/orders",
+			wantFilter: &models.OrderFilter{
+				SortBy: models.SortByDateCreated,
+				Limit:  defaultListLimit,
+			},
+			page:                 &models.OrderPage{Orders: []models.Order{}, NextCursor: "abc"},
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"next_cursor":"abc"`,
+		},
+		{
+			name: "фильтры и сортировка",
+			url:  "/orders?customer_id=test&provider=wbpay&currency=USD&locale=en&created_from=2025-07-01T00:00:00Z&sort=amount&order=asc&limit=5&cursor=xyz",
+			wantFilter: &models.OrderFilter{
+				CustomerID:  "test",
+				Provider:    "wbpay",
+				Currency:    "USD",
+				Locale:      "en",
+				CreatedFrom: &from,
+				SortBy:      models.SortByAmount,
+				Ascending:   true,
+				Cursor:      "xyz",
+				Limit:       5,
+			},
+			page:           &models.OrderPage{Orders: []models.Order{}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "неизвестная сортировка",
+			url:            "/orders?sort=name",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "слишком большой limit",
+			url:            "/orders?limit=1000",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "неправильная дата",
+			url:            "/orders?created_to=yesterday",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "неправильный курсор",
+			url:  "/orders?cursor=broken",
+			wantFilter: &models.OrderFilter{
+				SortBy: models.SortByDateCreated,
+				Limit:  defaultListLimit,
+				Cursor: "broken",
+			},
+			serviceErr:           fmt.Errorf("repo: %w", apperrors.ErrInvalidCursor),
+			expectedStatus:       http.StatusBadRequest,
+			expectedBodyContains: "неправильный курсор",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			svc := mock_service.NewMockorderLister(ctrl)
+			if tt.wantFilter != nil {
+				svc.EXPECT().ListOrders(gomock.Any(), *tt.wantFilter).Return(tt.page, tt.serviceErr)
+			}
+
+			h := NewListHandler(zaptest.NewLogger(t), svc)
+
+			w := httptest.NewRecorder()
+			h.ListOrders(w, httptest.NewRequest(http.MethodGet, tt.url, nil))
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBodyContains != "" {
+				assert.Contains(t, w.Body.String(), tt.expectedBodyContains)
+			}
+		})
+	}
+}
+
+func TestListHandler_GetOrdersByIDs(t *testing.T) {
+	first, second := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name                 string
+		url                  string
+		wantIDs              []uuid.UUID
+		orders               []models.Order
+		serviceErr           error
+		expectedStatus       int
+		expectedBodyContains []string
+	}{
+		{
+			name:           "найдены не все заказы",
+			url:            "/orders?ids=" + first.String() + ",%20" + second.String() + "," + first.String(),
+			wantIDs:        []uuid.UUID{first, second},
+			orders:         []models.Order{{OrderID: first}},
+			expectedStatus: http.StatusOK,
+			expectedBodyContains: []string{
+				`"order_uid":"` + first.String() + `"`,
+				`"not_found":["` + second.String() + `"]`,
+			},
+		},
+		{
+			name:           "неправильный uuid",
+			url:            "/orders?ids=" + first.String() + ",broken",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "пустой список",
+			url:            "/orders?ids=",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "ошибка бд",
+			url:            "/orders?ids=" + first.String(),
+			wantIDs:        []uuid.UUID{first},
+			serviceErr:     fmt.Errorf("repo: %w", apperrors.ErrGetOrdersByIDs),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			svc := mock_service.NewMockorderLister(ctrl)
+			if tt.wantIDs != nil {
+				svc.EXPECT().GetOrdersByIDs(gomock.Any(), tt.wantIDs).Return(tt.orders, tt.serviceErr)
+			}
+
+			h := NewListHandler(zaptest.NewLogger(t), svc)
+
+			w := httptest.NewRecorder()
+			h.ListOrders(w, httptest.NewRequest(http.MethodGet, tt.url, nil))
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			for _, s := range tt.expectedBodyContains {
+				assert.Contains(t, w.Body.String(), s)
+			}
+		})
+	}
+}
+
+func TestParseOrderIDs_Limit(t *testing.T) {
+	ids := make([]string, maxListLimit+1)
+	for i := range ids {
+		ids[i] = uuid.NewString()
+	}
+
+	_, err := parseOrderIDs(strings.Join(ids, ","))
+	assert.ErrorIs(t, err, apperrors.ErrInvalidQuery)
+}
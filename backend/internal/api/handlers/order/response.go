@@ -0,0 +1,51 @@
+package order
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+	"github.com/avraam311/order-service/backend/internal/pkg/idempotency"
+)
+
+func writeError(w http.ResponseWriter, l *zap.Logger, err error) {
+	writeResponse(w, errorResponse(l, err))
+}
+
+func errorResponse(l *zap.Logger, err error) idempotency.Response {
+	status := apperrors.HTTPStatus(err)
+	if status >= http.StatusInternalServerError {
+		l.Error("ошибка обработки запроса",
+			zap.String("code", string(apperrors.CodeOf(err))),
+			zap.Error(err),
+		)
+	}
+
+	return idempotency.Response{
+		Status:      status,
+		ContentType: "text/plain; charset=utf-8",
+		Body:        []byte(apperrors.Message(err) + "\n"),
+	}
+}
+
+func jsonResponse(status int, v any) idempotency.Response {
+	body, _ := json.Marshal(v)
+
+	return idempotency.Response{
+		Status:      status,
+		ContentType: "application/json",
+		Body:        append(body, '\n'),
+	}
+}
+
+func writeResponse(w http.ResponseWriter, resp idempotency.Response) {
+	if resp.Location != "" {
+		w.Header().Set("Location", resp.Location)
+	}
+	w.Header().Set("Content-Type", resp.ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(resp.Status)
+	w.Write(resp.Body)
+}
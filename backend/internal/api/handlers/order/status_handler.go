@@ -0,0 +1,78 @@
+package order
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+)
+
+const maxStatusBodySize = 1 << 12
+
+type statusChanger interface {
+	ChangeStatus(ctx context.Context, orderID uuid.UUID, to models.OrderStatus, changedBy, reason string) (*models.StatusChange, error)
+}
+
+type StatusHandler struct {
+	logger       *zap.Logger
+	orderService statusChanger
+}
+
+type changeStatusRequest struct {
+	Status    models.OrderStatus `json:"status"`
+	ChangedBy string             `json:"changed_by"`
+	Reason    string             `json:"reason"`
+}
+
+func NewStatusHandler(l *zap.Logger, s statusChanger) *StatusHandler {
+	return &StatusHandler{
+		logger:       l,
+		orderService: s,
+	}
+}
+
+func (h *StatusHandler) ChangeStatus(w http.ResponseWriter, r *http.Request) {
+	orderID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "неправильный формат uuid", http.StatusBadRequest)
+		return
+	}
+
+	if orderID == uuid.Nil {
+		http.Error(w, "нужно orderID", http.StatusBadRequest)
+		return
+	}
+
+	var req changeStatusRequest
+	if err = json.NewDecoder(http.MaxBytesReader(w, r.Body, maxStatusBodySize)).Decode(&req); err != nil {
+		writeError(w, h.logger, fmt.Errorf("%w: %w", apperrors.ErrInvalidJSON, err))
+		return
+	}
+
+	if req.Status == "" || req.ChangedBy == "" {
+		writeError(w, h.logger, fmt.Errorf("backend/internal/api/handlers/order/status_handler.go, нужны status и changed_by: %w", apperrors.ErrValidation))
+		return
+	}
+
+	change, err := h.orderService.ChangeStatus(r.Context(), orderID, req.Status, req.ChangedBy, req.Reason)
+	if err != nil {
+		writeError(w, h.logger, fmt.Errorf("backend/internal/api/handlers/order/status_handler.go, ошибка изменения статуса: %w", err))
+		return
+	}
+
+	h.logger.Info("статус заказа изменен",
+		zap.String("order_uid", orderID.String()),
+		zap.String("from", string(change.From)),
+		zap.String("to", string(change.To)),
+		zap.String("changed_by", change.ChangedBy),
+	)
+
+	writeResponse(w, jsonResponse(http.StatusOK, change))
+}
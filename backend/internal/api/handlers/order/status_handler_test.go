@@ -0,0 +1,109 @@
+package order
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zaptest"
+
+	mock_service "github.com/avraam311/order-service/backend/internal/mocks/service"
+	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+)
+
+func TestStatusHandler_ChangeStatus(t *testing.T) {
+	orderID := uuid.New()
+
+	tests := []struct {
+		name                 string
+		id                   string
+		body                 string
+		serviceErr           error
+		expectCall           bool
+		expectedStatus       int
+		expectedBodyContains string
+	}{
+		{
+			name:                 "статус изменен",
+			id:                   orderID.String(),
+			body:                 `{"status":"paid","changed_by":"operator","reason":"оплата получена"}`,
+			expectCall:           true,
+			expectedStatus:       http.StatusOK,
+			expectedBodyContains: `"to_status":"paid"`,
+		},
+		{
+			name:           "неправильный UUID",
+			id:             "invalid",
+			body:           `{"status":"paid","changed_by":"operator"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "неправильный json",
+			id:             orderID.String(),
+			body:           `{"status":`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "не указан автор",
+			id:             orderID.String(),
+			body:           `{"status":"paid"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:                 "недопустимый переход",
+			id:                   orderID.String(),
+			body:                 `{"status":"delivered","changed_by":"operator"}`,
+			serviceErr:           apperrors.ErrIllegalStatusTransition,
+			expectCall:           true,
+			expectedStatus:       http.StatusConflict,
+			expectedBodyContains: apperrors.ErrIllegalStatusTransition.Message,
+		},
+		{
+			name:           "заказ не найден",
+			id:             orderID.String(),
+			body:           `{"status":"paid","changed_by":"operator"}`,
+			serviceErr:     apperrors.ErrOrderNotFound,
+			expectCall:     true,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			svc := mock_service.NewMockstatusChanger(ctrl)
+			if tt.expectCall {
+				svc.EXPECT().ChangeStatus(gomock.Any(), orderID, gomock.Any(), "operator", gomock.Any()).DoAndReturn(
+					func(_ context.Context, id uuid.UUID, to models.OrderStatus, by, reason string) (*models.StatusChange, error) {
+						if tt.serviceErr != nil {
+							return nil, tt.serviceErr
+						}
+						return &models.StatusChange{OrderID: id, From: models.StatusCreated, To: to, ChangedBy: by, Reason: reason, ChangedAt: time.Now()}, nil
+					})
+			}
+
+			h := NewStatusHandler(zaptest.NewLogger(t), svc)
+
+			r := httptest.NewRequest(http.MethodPatch, "/orders/"+tt.id+"/status", strings.NewReader(tt.body))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.id)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			w := httptest.NewRecorder()
+			h.ChangeStatus(w, r)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.expectedBodyContains)
+		})
+	}
+}
@@ -11,7 +11,7 @@ import (
 	"github.com/avraam311/order-service/backend/internal/api/handlers/order"
 )
 
-func NewRouter(orderGetHandler *order.GetHandler) http.Handler {
+func NewRouter(orderGetHandler *order.GetHandler, orderListHandler *order.ListHandler, orderCreateHandler *order.CreateHandler, orderStatusHandler *order.StatusHandler) http.Handler {
 	r := chi.NewRouter()
 
 	r.Use(middleware.RequestID)
@@ -21,13 +21,16 @@ func NewRouter(orderGetHandler *order.GetHandler) http.Handler {
 	r.Use(middleware.Timeout(60 * time.Second))
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"http://*"},
-		AllowedMethods:   []string{"GET", "POST"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-		ExposedHeaders:   []string{"Link"},
+		AllowedMethods:   []string{"GET", "POST", "PATCH"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "Idempotency-Key"},
+		ExposedHeaders:   []string{"Link", "Location"},
 		AllowCredentials: false,
 	}))
 
+	r.Get("/orders", orderListHandler.ListOrders)
+	r.Post("/orders", orderCreateHandler.CreateOrder)
 	r.Get("/orders/{id}", orderGetHandler.GetOrderByID)
+	r.Patch("/orders/{id}/status", orderStatusHandler.ChangeStatus)
 
 	return r
 }
@@ -15,10 +15,14 @@ type Config struct {
 	Database Database `yaml:"database"`
 	Kafka    Kafka    `yaml:"kafka"`
 	Cache    Cache    `yaml:"cache"`
+	Outbox   Outbox   `yaml:"outbox"`
 }
 
 type Server struct {
-	HTTPPort string `yaml:"httpPort"`
+	HTTPPort                 string        `yaml:"httpPort"`
+	IdempotencyTTL           time.Duration `yaml:"idempotencyTTL"`
+	IdempotencyMaxKeys       int           `yaml:"idempotencyMaxKeys"`
+	IdempotencySweepInterval time.Duration `yaml:"idempotencySweepInterval"`
 }
 
 type Logger struct {
@@ -36,15 +40,94 @@ type Database struct {
 }
 
 type Kafka struct {
-	GroupID string   `yaml:"groupID"`
-	Topic   string   `yaml:"topic"`
-	Brokers []string `yaml:"brokers"`
+	GroupID           string   `yaml:"groupID"`
+	Topic             string   `yaml:"topic"`
+	Brokers           []string `yaml:"brokers"`
+	DLQ               DLQ      `yaml:"dlq"`
+	Retry             Retry    `yaml:"retry"`
+	Batch             Batch    `yaml:"batch"`
+	Workers           Workers  `yaml:"workers"`
+	SchemaRegistryDir string   `yaml:"schemaRegistryDir"`
+	Routes            []Route  `yaml:"routes"`
+}
+
+type Route struct {
+	Topic     string `yaml:"topic"`
+	EventType string `yaml:"eventType"`
+	Handler   string `yaml:"handler"`
+	Retry     *Retry `yaml:"retry"`
+	DLQ       *DLQ   `yaml:"dlq"`
+}
+
+type Workers struct {
+	Concurrency int    `yaml:"concurrency"`
+	OrderBy     string `yaml:"orderBy"`
+}
+
+type Batch struct {
+	Enabled bool          `yaml:"enabled"`
+	Size    int           `yaml:"size"`
+	Linger  time.Duration `yaml:"linger"`
+}
+
+type Retry struct {
+	MaxAttempts int           `yaml:"maxAttempts"`
+	BaseDelay   time.Duration `yaml:"baseDelay"`
+	MaxDelay    time.Duration `yaml:"maxDelay"`
+}
+
+type DLQ struct {
+	Enabled bool   `yaml:"enabled"`
+	Topic   string `yaml:"topic"`
+}
+
+type Outbox struct {
+	Enabled   bool          `yaml:"enabled"`
+	Topic     string        `yaml:"topic"`
+	Interval  time.Duration `yaml:"interval"`
+	BatchSize int           `yaml:"batchSize"`
+	Retention time.Duration `yaml:"retention"`
 }
 
 type Cache struct {
+	Backend           string        `yaml:"backend"`
 	DefaultExpiration time.Duration `yaml:"defaultExpiration"`
-	CleanupInterval   time.Duration `yaml:"cleanupInterval"`
+	NegativeTTL       time.Duration `yaml:"negativeTTL"`
+	MaxEntries        int           `yaml:"maxEntries"`
+	MaxBytes          int64         `yaml:"maxBytes"`
 	PreloadLimit      int           `yaml:"preloadLimit"`
+	Redis             Redis         `yaml:"redis"`
+	Snapshot          Snapshot      `yaml:"snapshot"`
+}
+
+type Snapshot struct {
+	Path   string        `yaml:"path"`
+	MaxAge time.Duration `yaml:"maxAge"`
+}
+
+type Redis struct {
+	Addr      string `yaml:"addr"`
+	Password  string
+	DB        int    `yaml:"db"`
+	KeyPrefix string `yaml:"keyPrefix"`
+}
+
+func (k Kafka) validate() error {
+	switch k.Workers.OrderBy {
+	case "", "partition", "key":
+	default:
+		return fmt.Errorf("kafka.workers.orderBy %q: ожидается partition или key", k.Workers.OrderBy)
+	}
+
+	if k.Batch.Enabled && k.Batch.Size > 1 && k.Batch.Linger <= 0 {
+		return fmt.Errorf("kafka.batch.linger должен быть больше нуля, если kafka.batch.size > 1")
+	}
+
+	if k.Batch.Enabled && k.Workers.Concurrency > 1 {
+		return fmt.Errorf("kafka.batch.enabled и kafka.workers.concurrency > 1 нельзя включить одновременно")
+	}
+
+	return nil
 }
 
 func (c *Config) DatabaseURL() string {
@@ -71,11 +154,16 @@ func MustLoad() *Config {
 		log.Fatalf("ошибка при десериализация конфига в структуру, %v", err)
 	}
 
+	if err := cfg.Kafka.validate(); err != nil {
+		log.Fatalf("некорректный конфиг: %v", err)
+	}
+
 	cfg.Database.Host = os.Getenv("DB_HOST")
 	cfg.Database.Port = os.Getenv("DB_PORT")
 	cfg.Database.User = os.Getenv("DB_USER")
 	cfg.Database.Password = os.Getenv("DB_PASSWORD")
 	cfg.Database.Name = os.Getenv("DB_NAME")
+	cfg.Cache.Redis.Password = os.Getenv("REDIS_PASSWORD")
 
 	return &cfg
 }
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKafka_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		kafka   Kafka
+		wantErr bool
+	}{
+		{name: "по умолчанию", kafka: Kafka{}},
+		{name: "воркеры по ключу", kafka: Kafka{Workers: Workers{Concurrency: 4, OrderBy: "key"}}},
+		{name: "пакетный режим с одним воркером", kafka: Kafka{Batch: Batch{Enabled: true, Size: 10, Linger: time.Second}, Workers: Workers{Concurrency: 1}}},
+		{name: "пакетный режим без linger", kafka: Kafka{Batch: Batch{Enabled: true, Size: 10}}, wantErr: true},
+		{name: "неизвестный orderBy", kafka: Kafka{Workers: Workers{Concurrency: 4, OrderBy: "offset"}}, wantErr: true},
+		{name: "пакетный режим вместе с воркерами", kafka: Kafka{Batch: Batch{Enabled: true, Size: 10, Linger: time.Second}, Workers: Workers{Concurrency: 4}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.kafka.validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
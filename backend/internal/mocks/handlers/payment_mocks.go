@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./backend/internal/pkg/kafka/handlers/payment_handler.go
+
+// Package mock_handlers is a generated GoMock package.
+package mock_handlers
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/avraam311/order-service/backend/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockpaymentUpdater is a mock of paymentUpdater interface.
+type MockpaymentUpdater struct {
+	ctrl     *gomock.Controller
+	recorder *MockpaymentUpdaterMockRecorder
+}
+
+// MockpaymentUpdaterMockRecorder is the mock recorder for MockpaymentUpdater.
+type MockpaymentUpdaterMockRecorder struct {
+	mock *MockpaymentUpdater
+}
+
+// NewMockpaymentUpdater creates a new mock instance.
+func NewMockpaymentUpdater(ctrl *gomock.Controller) *MockpaymentUpdater {
+	mock := &MockpaymentUpdater{ctrl: ctrl}
+	mock.recorder = &MockpaymentUpdaterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpaymentUpdater) EXPECT() *MockpaymentUpdaterMockRecorder {
+	return m.recorder
+}
+
+// UpdatePayment mocks base method.
+func (m *MockpaymentUpdater) UpdatePayment(ctx context.Context, orderID uuid.UUID, payment models.Payment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePayment", ctx, orderID, payment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePayment indicates an expected call of UpdatePayment.
+func (mr *MockpaymentUpdaterMockRecorder) UpdatePayment(ctx, orderID, payment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePayment", reflect.TypeOf((*MockpaymentUpdater)(nil).UpdatePayment), ctx, orderID, payment)
+}
@@ -36,19 +36,19 @@ func (m *MockorderRepository) EXPECT() *MockorderRepositoryMockRecorder {
 	return m.recorder
 }
 
-// GetItemsByOrderID mocks base method.
-func (m *MockorderRepository) GetItemsByOrderID(ctx context.Context, orderID uuid.UUID) ([]models.Item, error) {
+// GetFullOrder mocks base method.
+func (m *MockorderRepository) GetFullOrder(ctx context.Context, orderID uuid.UUID) (*models.Order, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetItemsByOrderID", ctx, orderID)
-	ret0, _ := ret[0].([]models.Item)
+	ret := m.ctrl.Call(m, "GetFullOrder", ctx, orderID)
+	ret0, _ := ret[0].(*models.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetItemsByOrderID indicates an expected call of GetItemsByOrderID.
-func (mr *MockorderRepositoryMockRecorder) GetItemsByOrderID(ctx, orderID interface{}) *gomock.Call {
+// GetFullOrder indicates an expected call of GetFullOrder.
+func (mr *MockorderRepositoryMockRecorder) GetFullOrder(ctx, orderID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemsByOrderID", reflect.TypeOf((*MockorderRepository)(nil).GetItemsByOrderID), ctx, orderID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFullOrder", reflect.TypeOf((*MockorderRepository)(nil).GetFullOrder), ctx, orderID)
 }
 
 // GetOrderById mocks base method.
@@ -66,6 +66,36 @@ func (mr *MockorderRepositoryMockRecorder) GetOrderById(ctx, orderID interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderById", reflect.TypeOf((*MockorderRepository)(nil).GetOrderById), ctx, orderID)
 }
 
+// GetOrdersByIDs mocks base method.
+func (m *MockorderRepository) GetOrdersByIDs(ctx context.Context, orderIDs []uuid.UUID) ([]models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrdersByIDs", ctx, orderIDs)
+	ret0, _ := ret[0].([]models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrdersByIDs indicates an expected call of GetOrdersByIDs.
+func (mr *MockorderRepositoryMockRecorder) GetOrdersByIDs(ctx, orderIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersByIDs", reflect.TypeOf((*MockorderRepository)(nil).GetOrdersByIDs), ctx, orderIDs)
+}
+
+// ListOrders mocks base method.
+func (m *MockorderRepository) ListOrders(ctx context.Context, f models.OrderFilter) (*models.OrderPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrders", ctx, f)
+	ret0, _ := ret[0].(*models.OrderPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrders indicates an expected call of ListOrders.
+func (mr *MockorderRepositoryMockRecorder) ListOrders(ctx, f interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrders", reflect.TypeOf((*MockorderRepository)(nil).ListOrders), ctx, f)
+}
+
 // SaveOrder mocks base method.
 func (m *MockorderRepository) SaveOrder(ctx context.Context, order *models.Order) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
@@ -81,6 +111,49 @@ func (mr *MockorderRepositoryMockRecorder) SaveOrder(ctx, order interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOrder", reflect.TypeOf((*MockorderRepository)(nil).SaveOrder), ctx, order)
 }
 
+// SaveOrders mocks base method.
+func (m *MockorderRepository) SaveOrders(ctx context.Context, orders []*models.Order) ([]error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveOrders", ctx, orders)
+	ret0, _ := ret[0].([]error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveOrders indicates an expected call of SaveOrders.
+func (mr *MockorderRepositoryMockRecorder) SaveOrders(ctx, orders interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOrders", reflect.TypeOf((*MockorderRepository)(nil).SaveOrders), ctx, orders)
+}
+
+// UpdateOrderStatus mocks base method.
+func (m *MockorderRepository) UpdateOrderStatus(ctx context.Context, change models.StatusChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrderStatus", ctx, change)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOrderStatus indicates an expected call of UpdateOrderStatus.
+func (mr *MockorderRepositoryMockRecorder) UpdateOrderStatus(ctx, change interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderStatus", reflect.TypeOf((*MockorderRepository)(nil).UpdateOrderStatus), ctx, change)
+}
+
+// UpdatePayment mocks base method.
+func (m *MockorderRepository) UpdatePayment(ctx context.Context, orderID uuid.UUID, p models.Payment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePayment", ctx, orderID, p)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePayment indicates an expected call of UpdatePayment.
+func (mr *MockorderRepositoryMockRecorder) UpdatePayment(ctx, orderID, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePayment", reflect.TypeOf((*MockorderRepository)(nil).UpdatePayment), ctx, orderID, p)
+}
+
 // MockorderCache is a mock of orderCache interface.
 type MockorderCache struct {
 	ctrl     *gomock.Controller
@@ -104,6 +177,18 @@ func (m *MockorderCache) EXPECT() *MockorderCacheMockRecorder {
 	return m.recorder
 }
 
+// Delete mocks base method.
+func (m *MockorderCache) Delete(orderID uuid.UUID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Delete", orderID)
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockorderCacheMockRecorder) Delete(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockorderCache)(nil).Delete), orderID)
+}
+
 // Get mocks base method.
 func (m *MockorderCache) Get(orderID uuid.UUID) (*models.Order, bool) {
 	m.ctrl.T.Helper()
@@ -130,3 +215,15 @@ func (mr *MockorderCacheMockRecorder) Set(orderID, order interface{}) *gomock.Ca
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockorderCache)(nil).Set), orderID, order)
 }
+
+// SetNotFound mocks base method.
+func (m *MockorderCache) SetNotFound(orderID uuid.UUID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetNotFound", orderID)
+}
+
+// SetNotFound indicates an expected call of SetNotFound.
+func (mr *MockorderCacheMockRecorder) SetNotFound(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNotFound", reflect.TypeOf((*MockorderCache)(nil).SetNotFound), orderID)
+}
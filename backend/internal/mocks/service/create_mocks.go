@@ -0,0 +1,152 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./backend/internal/api/handlers/order/create_handler.go
+
+// Package mock_order is a generated GoMock package.
+package mock_order
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/avraam311/order-service/backend/internal/models"
+	idempotency "github.com/avraam311/order-service/backend/internal/pkg/idempotency"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockorderCreator is a mock of orderCreator interface.
+type MockorderCreator struct {
+	ctrl     *gomock.Controller
+	recorder *MockorderCreatorMockRecorder
+}
+
+// MockorderCreatorMockRecorder is the mock recorder for MockorderCreator.
+type MockorderCreatorMockRecorder struct {
+	mock *MockorderCreator
+}
+
+// NewMockorderCreator creates a new mock instance.
+func NewMockorderCreator(ctrl *gomock.Controller) *MockorderCreator {
+	mock := &MockorderCreator{ctrl: ctrl}
+	mock.recorder = &MockorderCreatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockorderCreator) EXPECT() *MockorderCreatorMockRecorder {
+	return m.recorder
+}
+
+// SaveOrder mocks base method.
+func (m *MockorderCreator) SaveOrder(ctx context.Context, order *models.Order) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveOrder", ctx, order)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveOrder indicates an expected call of SaveOrder.
+func (mr *MockorderCreatorMockRecorder) SaveOrder(ctx, order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOrder", reflect.TypeOf((*MockorderCreator)(nil).SaveOrder), ctx, order)
+}
+
+// Mockvalidator is a mock of validator interface.
+type Mockvalidator struct {
+	ctrl     *gomock.Controller
+	recorder *MockvalidatorMockRecorder
+}
+
+// MockvalidatorMockRecorder is the mock recorder for Mockvalidator.
+type MockvalidatorMockRecorder struct {
+	mock *Mockvalidator
+}
+
+// NewMockvalidator creates a new mock instance.
+func NewMockvalidator(ctrl *gomock.Controller) *Mockvalidator {
+	mock := &Mockvalidator{ctrl: ctrl}
+	mock.recorder = &MockvalidatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockvalidator) EXPECT() *MockvalidatorMockRecorder {
+	return m.recorder
+}
+
+// Validate mocks base method.
+func (m *Mockvalidator) Validate(i interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", i)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockvalidatorMockRecorder) Validate(i interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*Mockvalidator)(nil).Validate), i)
+}
+
+// MockidempotencyStore is a mock of idempotencyStore interface.
+type MockidempotencyStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockidempotencyStoreMockRecorder
+}
+
+// MockidempotencyStoreMockRecorder is the mock recorder for MockidempotencyStore.
+type MockidempotencyStoreMockRecorder struct {
+	mock *MockidempotencyStore
+}
+
+// NewMockidempotencyStore creates a new mock instance.
+func NewMockidempotencyStore(ctrl *gomock.Controller) *MockidempotencyStore {
+	mock := &MockidempotencyStore{ctrl: ctrl}
+	mock.recorder = &MockidempotencyStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockidempotencyStore) EXPECT() *MockidempotencyStoreMockRecorder {
+	return m.recorder
+}
+
+// Abort mocks base method.
+func (m *MockidempotencyStore) Abort(key string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Abort", key)
+}
+
+// Abort indicates an expected call of Abort.
+func (mr *MockidempotencyStoreMockRecorder) Abort(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Abort", reflect.TypeOf((*MockidempotencyStore)(nil).Abort), key)
+}
+
+// Begin mocks base method.
+func (m *MockidempotencyStore) Begin(key, fingerprint string) (*idempotency.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Begin", key, fingerprint)
+	ret0, _ := ret[0].(*idempotency.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Begin indicates an expected call of Begin.
+func (mr *MockidempotencyStoreMockRecorder) Begin(key, fingerprint interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Begin", reflect.TypeOf((*MockidempotencyStore)(nil).Begin), key, fingerprint)
+}
+
+// Complete mocks base method.
+func (m *MockidempotencyStore) Complete(key string, resp idempotency.Response) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Complete", key, resp)
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockidempotencyStoreMockRecorder) Complete(key, resp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockidempotencyStore)(nil).Complete), key, resp)
+}
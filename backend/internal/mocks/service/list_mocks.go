@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./backend/internal/api/handlers/order/list_handler.go
+
+// Package mock_order is a generated GoMock package.
+package mock_order
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/avraam311/order-service/backend/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockorderLister is a mock of orderLister interface.
+type MockorderLister struct {
+	ctrl     *gomock.Controller
+	recorder *MockorderListerMockRecorder
+}
+
+// MockorderListerMockRecorder is the mock recorder for MockorderLister.
+type MockorderListerMockRecorder struct {
+	mock *MockorderLister
+}
+
+// NewMockorderLister creates a new mock instance.
+func NewMockorderLister(ctrl *gomock.Controller) *MockorderLister {
+	mock := &MockorderLister{ctrl: ctrl}
+	mock.recorder = &MockorderListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockorderLister) EXPECT() *MockorderListerMockRecorder {
+	return m.recorder
+}
+
+// GetOrdersByIDs mocks base method.
+func (m *MockorderLister) GetOrdersByIDs(ctx context.Context, orderIDs []uuid.UUID) ([]models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrdersByIDs", ctx, orderIDs)
+	ret0, _ := ret[0].([]models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrdersByIDs indicates an expected call of GetOrdersByIDs.
+func (mr *MockorderListerMockRecorder) GetOrdersByIDs(ctx, orderIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersByIDs", reflect.TypeOf((*MockorderLister)(nil).GetOrdersByIDs), ctx, orderIDs)
+}
+
+// ListOrders mocks base method.
+func (m *MockorderLister) ListOrders(ctx context.Context, f models.OrderFilter) (*models.OrderPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrders", ctx, f)
+	ret0, _ := ret[0].(*models.OrderPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrders indicates an expected call of ListOrders.
+func (mr *MockorderListerMockRecorder) ListOrders(ctx, f interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrders", reflect.TypeOf((*MockorderLister)(nil).ListOrders), ctx, f)
+}
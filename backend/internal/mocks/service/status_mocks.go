@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./backend/internal/api/handlers/order/status_handler.go
+
+// Package mock_order is a generated GoMock package.
+package mock_order
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/avraam311/order-service/backend/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockstatusChanger is a mock of statusChanger interface.
+type MockstatusChanger struct {
+	ctrl     *gomock.Controller
+	recorder *MockstatusChangerMockRecorder
+}
+
+// MockstatusChangerMockRecorder is the mock recorder for MockstatusChanger.
+type MockstatusChangerMockRecorder struct {
+	mock *MockstatusChanger
+}
+
+// NewMockstatusChanger creates a new mock instance.
+func NewMockstatusChanger(ctrl *gomock.Controller) *MockstatusChanger {
+	mock := &MockstatusChanger{ctrl: ctrl}
+	mock.recorder = &MockstatusChangerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockstatusChanger) EXPECT() *MockstatusChangerMockRecorder {
+	return m.recorder
+}
+
+// ChangeStatus mocks base method.
+func (m *MockstatusChanger) ChangeStatus(ctx context.Context, orderID uuid.UUID, to models.OrderStatus, changedBy, reason string) (*models.StatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeStatus", ctx, orderID, to, changedBy, reason)
+	ret0, _ := ret[0].(*models.StatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeStatus indicates an expected call of ChangeStatus.
+func (mr *MockstatusChangerMockRecorder) ChangeStatus(ctx, orderID, to, changedBy, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeStatus", reflect.TypeOf((*MockstatusChanger)(nil).ChangeStatus), ctx, orderID, to, changedBy, reason)
+}
@@ -2,25 +2,26 @@ package models
 
 import (
 	"time"
-	
+
 	"github.com/google/uuid"
 )
 
 type Order struct {
-	OrderID           uuid.UUID `json:"order_uid" validate:"required"`
-	TrackNumber       string    `json:"track_number" validate:"required"`
-	Entry             string    `json:"entry" validate:"required"`
-	Delivery          Delivery  `json:"delivery" validate:"required"`
-	Payment           Payment   `json:"payment" validate:"required"`
-	Items             []Item    `json:"items" validate:"required,min=1,dive"`
-	Locale            string    `json:"locale" validate:"required"`
-	InternalSignature string    `json:"internal_signature"`
-	CustomerId        string    `json:"customer_id" validate:"required"`
-	DeliveryService   string    `json:"delivery_service" validate:"required"`
-	Shardkey          string    `json:"shardkey" validate:"required"`
-	SmId              int       `json:"sm_id" validate:"required"`
-	DateCreated       time.Time `json:"date_created"`
-	OofShard          string    `json:"oof_shard" validate:"required"`
+	OrderID           uuid.UUID   `json:"order_uid" validate:"required"`
+	TrackNumber       string      `json:"track_number" validate:"required"`
+	Entry             string      `json:"entry" validate:"required"`
+	Delivery          Delivery    `json:"delivery" validate:"required"`
+	Payment           Payment     `json:"payment" validate:"required"`
+	Items             []Item      `json:"items" validate:"required,min=1,dive"`
+	Locale            string      `json:"locale" validate:"required"`
+	InternalSignature string      `json:"internal_signature"`
+	CustomerId        string      `json:"customer_id" validate:"required"`
+	DeliveryService   string      `json:"delivery_service" validate:"required"`
+	Shardkey          string      `json:"shardkey" validate:"required"`
+	SmId              int         `json:"sm_id" validate:"required"`
+	DateCreated       time.Time   `json:"date_created"`
+	OofShard          string      `json:"oof_shard" validate:"required"`
+	Status            OrderStatus `json:"status"`
 }
 
 type Delivery struct {
@@ -59,3 +60,59 @@ type Item struct {
 	Brand       string `json:"brand" validate:"required"`
 	Status      int    `json:"status" validate:"required"`
 }
+
+type OrderSortField string
+
+const (
+	SortByDateCreated OrderSortField = "date_created"
+	SortByAmount      OrderSortField = "amount"
+)
+
+type OrderFilter struct {
+	CustomerID      string
+	TrackNumber     string
+	DeliveryService string
+	Provider        string
+	Currency        string
+	Locale          string
+	CreatedFrom     *time.Time
+	CreatedTo       *time.Time
+	SortBy          OrderSortField
+	Ascending       bool
+	Cursor          string
+	Limit           int
+}
+
+type OrderPage struct {
+	Orders     []Order `json:"orders"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+}
+
+type OrderStatus string
+
+const (
+	StatusCreated    OrderStatus = "created"
+	StatusPaid       OrderStatus = "paid"
+	StatusAssembling OrderStatus = "assembling"
+	StatusShipped    OrderStatus = "shipped"
+	StatusDelivered  OrderStatus = "delivered"
+	StatusCancelled  OrderStatus = "cancelled"
+	StatusReturned   OrderStatus = "returned"
+)
+
+type OutboxEvent struct {
+	ID        int64
+	OrderID   uuid.UUID
+	EventType string
+	Payload   []byte
+	CreatedAt time.Time
+}
+
+type StatusChange struct {
+	OrderID   uuid.UUID   `json:"order_uid"`
+	From      OrderStatus `json:"from_status"`
+	To        OrderStatus `json:"to_status"`
+	ChangedBy string      `json:"changed_by"`
+	Reason    string      `json:"reason,omitempty"`
+	ChangedAt time.Time   `json:"changed_at"`
+}
@@ -0,0 +1,26 @@
+// Package modeltest содержит общий для тестов эталонный заказ.
+package modeltest
+
+import (
+	_ "embed"
+	"encoding/json"
+	"testing"
+
+	"github.com/avraam311/order-service/backend/internal/models"
+)
+
+//go:embed testdata/order.json
+var orderJSON []byte
+
+// Order возвращает новую копию эталонного заказа из testdata/order.json,
+// которую тест может менять.
+func Order(tb testing.TB) *models.Order {
+	tb.Helper()
+
+	var o models.Order
+	if err := json.Unmarshal(orderJSON, &o); err != nil {
+		tb.Fatalf("разбор testdata/order.json: %v", err)
+	}
+
+	return &o
+}
@@ -0,0 +1,173 @@
+package apperrors
+
+import (
+	"errors"
+	"net/http"
+)
+
+type Code string
+
+const (
+	CodeUnknown        Code = "unknown"
+	CodeInvalidJSON    Code = "invalid_json"
+	CodeEmptyOrder     Code = "empty_order"
+	CodeValidation     Code = "validation"
+	CodeOrderNotFound  Code = "order_not_found"
+	CodeScanRow        Code = "scan_row"
+	CodeGetItems       Code = "get_items"
+	CodeScanItems      Code = "scan_items"
+	CodeGetLastOrders  Code = "get_last_orders"
+	CodeTxBegin        Code = "tx_begin"
+	CodeTxCommit       Code = "tx_commit"
+	CodeTxRollback     Code = "tx_rollback"
+	CodeInsertOrder    Code = "insert_order"
+	CodeInsertDelivery Code = "insert_delivery"
+	CodeInsertPayment  Code = "insert_payment"
+	CodeInsertItem     Code = "insert_item"
+	CodeDBConnection   Code = "db_connection"
+	CodeCachePreload   Code = "cache_preload"
+	CodeDLQPublish     Code = "dlq_publish"
+	CodeInvalidCursor  Code = "invalid_cursor"
+	CodeInvalidQuery   Code = "invalid_query"
+	CodeListOrders     Code = "list_orders"
+	CodeOrderDuplicate Code = "order_duplicate"
+	CodeOrderConflict  Code = "order_conflict"
+	CodeIdempotencyKey Code = "idempotency_key"
+	CodeInProgress     Code = "in_progress"
+	CodeKeyStoreFull   Code = "idempotency_store_full"
+	CodeInvalidStatus  Code = "invalid_status"
+	CodeIllegalStatus  Code = "illegal_status_transition"
+	CodeStatusConflict Code = "status_conflict"
+	CodeUpdateStatus   Code = "update_status"
+	CodeCacheSnapshot  Code = "cache_snapshot"
+	CodeGetOrders      Code = "get_orders"
+	CodeUnknownSchema  Code = "unknown_schema_version"
+	CodeUnknownEvent   Code = "unknown_event_type"
+	CodeInvalidMessage Code = "invalid_message"
+	CodeContentType    Code = "unsupported_content_type"
+	CodeUpdatePayment  Code = "update_payment"
+	CodeOutbox         Code = "outbox"
+	CodeOutboxPublish  Code = "outbox_publish"
+)
+
+var (
+	ErrInvalidJSON             = New(CodeInvalidJSON, "неправильный json", http.StatusBadRequest, false)
+	ErrEmptyOrder              = New(CodeEmptyOrder, "пустой заказ", http.StatusBadRequest, false)
+	ErrValidation              = New(CodeValidation, "ошибка валидации", http.StatusUnprocessableEntity, false)
+	ErrOrderNotFound           = New(CodeOrderNotFound, "заказ не найден", http.StatusNotFound, false)
+	ErrScanRow                 = New(CodeScanRow, "ошибка сканирования строки", http.StatusInternalServerError, false)
+	ErrGetItemsByOrderId       = New(CodeGetItems, "ошибка получения items по orderID", http.StatusInternalServerError, false)
+	ErrItemScanFailed          = New(CodeScanItems, "ошибка сканирования items заказа", http.StatusInternalServerError, false)
+	ErrGetLastOrders           = New(CodeGetLastOrders, "ошибка при получении последних заказов", http.StatusInternalServerError, false)
+	ErrTxBegin                 = New(CodeTxBegin, "ошибка при начале транзакции", http.StatusServiceUnavailable, true)
+	ErrTxCommit                = New(CodeTxCommit, "ошибка при применении транзакции", http.StatusServiceUnavailable, true)
+	ErrTxRollback              = New(CodeTxRollback, "ошибка при откате транзакции", http.StatusServiceUnavailable, true)
+	ErrInsertOrder             = New(CodeInsertOrder, "ошибка при добавлении orders", http.StatusInternalServerError, false)
+	ErrInsertDelivery          = New(CodeInsertDelivery, "ошибка при добавлении delivery", http.StatusInternalServerError, false)
+	ErrInsertPayment           = New(CodeInsertPayment, "ошибка при добавлении payment", http.StatusInternalServerError, false)
+	ErrInsertItem              = New(CodeInsertItem, "ошибка при добавлении items", http.StatusInternalServerError, false)
+	ErrDBConnection            = New(CodeDBConnection, "ошибка соединения с бд", http.StatusServiceUnavailable, true)
+	ErrCachePreload            = New(CodeCachePreload, "ошибка загрузки кэша", http.StatusInternalServerError, false)
+	ErrDLQPublish              = New(CodeDLQPublish, "ошибка отправки сообщения в dlq", http.StatusInternalServerError, true)
+	ErrInvalidCursor           = New(CodeInvalidCursor, "неправильный курсор", http.StatusBadRequest, false)
+	ErrInvalidQuery            = New(CodeInvalidQuery, "неправильные параметры запроса", http.StatusBadRequest, false)
+	ErrListOrders              = New(CodeListOrders, "ошибка при получении списка заказов", http.StatusInternalServerError, false)
+	ErrOrderDuplicate          = New(CodeOrderDuplicate, "заказ уже сохранен с такими же данными", http.StatusConflict, false)
+	ErrOrderConflict           = New(CodeOrderConflict, "заказ с таким order_uid уже существует с другими данными", http.StatusConflict, false)
+	ErrIdempotencyKeyReused    = New(CodeIdempotencyKey, "Idempotency-Key уже использован с другим телом запроса", http.StatusUnprocessableEntity, false)
+	ErrIdempotencyInProgress   = New(CodeInProgress, "запрос с таким Idempotency-Key еще обрабатывается", http.StatusConflict, true)
+	ErrIdempotencyStoreFull    = New(CodeKeyStoreFull, "слишком много активных Idempotency-Key, повторите позже", http.StatusTooManyRequests, true)
+	ErrInvalidStatus           = New(CodeInvalidStatus, "неизвестный статус заказа", http.StatusBadRequest, false)
+	ErrIllegalStatusTransition = New(CodeIllegalStatus, "недопустимый переход статуса заказа", http.StatusConflict, false)
+	ErrStatusConflict          = New(CodeStatusConflict, "статус заказа был изменен параллельно", http.StatusConflict, true)
+	ErrUpdateStatus            = New(CodeUpdateStatus, "ошибка при изменении статуса заказа", http.StatusInternalServerError, false)
+	ErrGetOrdersByIDs          = New(CodeGetOrders, "ошибка при получении заказов по id", http.StatusInternalServerError, false)
+	ErrCacheSnapshot           = New(CodeCacheSnapshot, "ошибка снимка кэша", http.StatusInternalServerError, false)
+	ErrUnknownSchemaVersion    = New(CodeUnknownSchema, "неизвестная версия схемы сообщения", http.StatusBadRequest, false)
+	ErrUnknownEventType        = New(CodeUnknownEvent, "неизвестный тип события", http.StatusBadRequest, false)
+	ErrInvalidMessage          = New(CodeInvalidMessage, "сообщение не удалось разобрать", http.StatusBadRequest, false)
+	ErrUnsupportedContentType  = New(CodeContentType, "неподдерживаемый content-type сообщения", http.StatusUnsupportedMediaType, false)
+	ErrUpdatePayment           = New(CodeUpdatePayment, "ошибка при изменении payment", http.StatusInternalServerError, false)
+	ErrOutbox                  = New(CodeOutbox, "ошибка при работе с outbox", http.StatusInternalServerError, false)
+	ErrOutboxPublish           = New(CodeOutboxPublish, "ошибка публикации событий из outbox", http.StatusServiceUnavailable, true)
+)
+
+type Error struct {
+	Code       Code
+	Message    string
+	HTTPStatus int
+	Retryable  bool
+}
+
+func New(code Code, message string, httpStatus int, retryable bool) *Error {
+	return &Error{
+		Code:       code,
+		Message:    message,
+		HTTPStatus: httpStatus,
+		Retryable:  retryable,
+	}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func As(err error) (*Error, bool) {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr, true
+	}
+
+	return nil, false
+}
+
+func CodeOf(err error) Code {
+	if appErr, ok := As(err); ok {
+		return appErr.Code
+	}
+
+	return CodeUnknown
+}
+
+func HTTPStatus(err error) int {
+	if appErr, ok := As(err); ok {
+		return appErr.HTTPStatus
+	}
+
+	return http.StatusInternalServerError
+}
+
+func Message(err error) string {
+	if appErr, ok := As(err); ok {
+		return appErr.Message
+	}
+
+	return "ошибка сервера"
+}
+
+func IsRetryable(err error) bool {
+	return anyError(err, func(e *Error) bool { return e.Retryable })
+}
+
+func anyError(err error, match func(e *Error) bool) bool {
+	if err == nil {
+		return false
+	}
+
+	if appErr, ok := err.(*Error); ok && match(appErr) {
+		return true
+	}
+
+	switch x := err.(type) {
+	case interface{ Unwrap() error }:
+		return anyError(x.Unwrap(), match)
+	case interface{ Unwrap() []error }:
+		for _, e := range x.Unwrap() {
+			if anyError(e, match) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,70 @@
+package apperrors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassification(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		wantCode      Code
+		wantStatus    int
+		wantRetryable bool
+		wantIs        error
+	}{
+		{
+			name:       "обернутый not found",
+			err:        fmt.Errorf("handler: %w", fmt.Errorf("repo: %w", ErrOrderNotFound)),
+			wantCode:   CodeOrderNotFound,
+			wantStatus: http.StatusNotFound,
+			wantIs:     ErrOrderNotFound,
+		},
+		{
+			name:          "ошибка вставки из-за соединения",
+			err:           fmt.Errorf("создание: %w", fmt.Errorf("repo: %w: %w", ErrInsertOrder, ErrDBConnection)),
+			wantCode:      CodeInsertOrder,
+			wantStatus:    http.StatusInternalServerError,
+			wantRetryable: true,
+			wantIs:        ErrDBConnection,
+		},
+		{
+			name:          "errors.Join",
+			err:           errors.Join(errors.New("first"), ErrTxCommit),
+			wantCode:      CodeTxCommit,
+			wantStatus:    http.StatusServiceUnavailable,
+			wantRetryable: true,
+			wantIs:        ErrTxCommit,
+		},
+		{
+			name:          "гонка при смене статуса",
+			err:           fmt.Errorf("ошибка изменения статуса заказа: %w", ErrStatusConflict),
+			wantCode:      CodeStatusConflict,
+			wantStatus:    http.StatusConflict,
+			wantRetryable: true,
+			wantIs:        ErrStatusConflict,
+		},
+		{
+			name:       "нетипизированная ошибка",
+			err:        errors.New("boom"),
+			wantCode:   CodeUnknown,
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantCode, CodeOf(tt.err))
+			assert.Equal(t, tt.wantStatus, HTTPStatus(tt.err))
+			assert.Equal(t, tt.wantRetryable, IsRetryable(tt.err))
+			if tt.wantIs != nil {
+				assert.ErrorIs(t, tt.err, tt.wantIs)
+			}
+		})
+	}
+}
@@ -1,72 +1,197 @@
 package cache
 
 import (
-	"context"
-	"errors"
-	"fmt"
+	"container/list"
+	"sync"
 	"time"
+	"unsafe"
 
 	"github.com/google/uuid"
-	"github.com/patrickmn/go-cache"
-	"go.uber.org/zap"
 
 	"github.com/avraam311/order-service/backend/internal/models"
 )
 
-var (
-	ErrCachePreload = errors.New("ошибка загрузки кэша")
-)
+type entry struct {
+	orderID   uuid.UUID
+	order     *models.Order
+	size      int64
+	expiresAt time.Time
+}
 
-type orderRepository interface {
-	GetLastOrders(ctx context.Context, limit int) ([]models.Order, error)
+type Stats struct {
+	Entries      int   `json:"entries"`
+	Bytes        int64 `json:"bytes"`
+	Hits         int64 `json:"hits"`
+	NegativeHits int64 `json:"negative_hits"`
+	Misses       int64 `json:"misses"`
+	Evictions    int64 `json:"evictions"`
+	Expirations  int64 `json:"expirations"`
 }
 
-type GoCache struct {
-	c      *cache.Cache
-	logger *zap.Logger
-	repo   orderRepository
+type LRU struct {
+	maxEntries  int
+	maxBytes    int64
+	ttl         time.Duration
+	negativeTTL time.Duration
+	now         func() time.Time
+
+	mu    sync.Mutex
+	ll    *list.List
+	items map[uuid.UUID]*list.Element
+	bytes int64
+	stats Stats
 }
 
-func New(defaultExpiration, cleanupInterval time.Duration, l *zap.Logger, r orderRepository) *GoCache {
-	return &GoCache{
-		c:      cache.New(defaultExpiration, cleanupInterval),
-		logger: l,
-		repo:   r,
+func New(maxEntries int, maxBytes int64, ttl, negativeTTL time.Duration) *LRU {
+	return &LRU{
+		maxEntries:  maxEntries,
+		maxBytes:    maxBytes,
+		ttl:         ttl,
+		negativeTTL: negativeTTL,
+		now:         time.Now,
+		ll:          list.New(),
+		items:       make(map[uuid.UUID]*list.Element),
 	}
 }
 
-func (g *GoCache) Get(orderID uuid.UUID) (*models.Order, bool) {
-	val, found := g.c.Get(orderID.String())
-	if !found {
+func (c *LRU) Get(orderID uuid.UUID) (*models.Order, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[orderID]
+	if !ok {
+		c.stats.Misses++
 		return nil, false
 	}
 
-	order, ok := val.(*models.Order)
+	e := el.Value.(*entry)
+	if !e.expiresAt.IsZero() && !c.now().Before(e.expiresAt) {
+		c.removeElement(el)
+		c.stats.Expirations++
+		c.stats.Misses++
+		return nil, false
+	}
+
+	c.ll.MoveToFront(el)
+	c.stats.Hits++
+	if e.order == nil {
+		c.stats.NegativeHits++
+	}
+
+	return e.order, true
+}
+
+func (c *LRU) Set(orderID uuid.UUID, order *models.Order) {
+	c.SetWithTTL(orderID, order, c.ttl)
+}
+
+func (c *LRU) SetNotFound(orderID uuid.UUID) {
+	if c.negativeTTL <= 0 {
+		return
+	}
+
+	c.SetWithTTL(orderID, nil, c.negativeTTL)
+}
+
+func (c *LRU) SetWithTTL(orderID uuid.UUID, order *models.Order, ttl time.Duration) {
+	size := orderSize(order)
+	if c.maxBytes > 0 && size > c.maxBytes {
+		// Новая версия не влезает в кэш, но и старую отдавать уже нельзя.
+		c.Delete(orderID)
+		return
+	}
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = c.now().Add(ttl)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
-	return order, ok
+	if el, ok := c.items[orderID]; ok {
+		e := el.Value.(*entry)
+		c.bytes += size - e.size
+		e.order, e.size, e.expiresAt = order, size, expiresAt
+		c.ll.MoveToFront(el)
+	} else {
+		c.items[orderID] = c.ll.PushFront(&entry{orderID: orderID, order: order, size: size, expiresAt: expiresAt})
+		c.bytes += size
+	}
+
+	for c.overBudget() {
+		c.removeElement(c.ll.Back())
+		c.stats.Evictions++
+	}
 }
 
-func (g *GoCache) Set(orderID uuid.UUID, order *models.Order) {
-	g.c.Set(orderID.String(), order, cache.DefaultExpiration)
+func (c *LRU) Delete(orderID uuid.UUID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[orderID]; ok {
+		c.removeElement(el)
+	}
 }
 
-func (g *GoCache) Preload(ctx context.Context, limit int) error {
-	orders, err := g.repo.GetLastOrders(ctx, limit)
-	if err != nil {
-		g.logger.Error("ошибка загрузки кэша", zap.Error(err))
-		return fmt.Errorf("backend/internal/pkg/cache/cache.go, ошибка загрузки кэша: %w", ErrCachePreload)
+func (c *LRU) Flush() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ll.Init()
+	c.items = make(map[uuid.UUID]*list.Element)
+	c.bytes = 0
+}
+
+// FlushLocal очищает кэш после переподключения к бд. Для LRU это то же, что
+// Flush.
+func (c *LRU) FlushLocal() {
+	c.Flush()
+}
+
+func (c *LRU) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s := c.stats
+	s.Entries = c.ll.Len()
+	s.Bytes = c.bytes
+
+	return s
+}
+
+func (c *LRU) overBudget() bool {
+	if c.ll.Len() == 0 {
+		return false
 	}
 
-	if len(orders) == 0 {
-		g.logger.Info("нет заказов для загрузки в кэш")
-		return nil
+	return (c.maxEntries > 0 && c.ll.Len() > c.maxEntries) || (c.maxBytes > 0 && c.bytes > c.maxBytes)
+}
+
+func (c *LRU) removeElement(el *list.Element) {
+	e := c.ll.Remove(el).(*entry)
+	delete(c.items, e.orderID)
+	c.bytes -= e.size
+}
+
+func orderSize(o *models.Order) int64 {
+	if o == nil {
+		return int64(unsafe.Sizeof(entry{}))
 	}
 
-	for _, order := range orders {
-		o := order
-		g.Set(order.OrderID, &o)
+	size := unsafe.Sizeof(entry{}) + unsafe.Sizeof(*o) +
+		uintptr(len(o.TrackNumber)+len(o.Entry)+len(o.Locale)+len(o.InternalSignature)+len(o.CustomerId)+
+			len(o.DeliveryService)+len(o.Shardkey)+len(o.OofShard)+len(o.Status))
+
+	d := o.Delivery
+	size += uintptr(len(d.Name) + len(d.Phone) + len(d.Zip) + len(d.City) + len(d.Address) + len(d.Region) + len(d.Email))
+
+	p := o.Payment
+	size += uintptr(len(p.Transaction) + len(p.RequestID) + len(p.Currency) + len(p.Provider) + len(p.Bank))
+
+	for _, it := range o.Items {
+		size += unsafe.Sizeof(it) + uintptr(len(it.TrackNumber)+len(it.RID)+len(it.Name)+len(it.Size)+len(it.Brand))
 	}
 
-	g.logger.Info("кэш загружен успешно", zap.Int("orders_count", len(orders)))
-	return nil
+	return int64(size)
 }
@@ -0,0 +1,133 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avraam311/order-service/backend/internal/models"
+)
+
+func TestLRU_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := New(2, 0, 0, 0)
+
+	a, b, d := uuid.New(), uuid.New(), uuid.New()
+	c.Set(a, &models.Order{OrderID: a})
+	c.Set(b, &models.Order{OrderID: b})
+
+	_, ok := c.Get(a)
+	require.True(t, ok)
+
+	c.Set(d, &models.Order{OrderID: d})
+
+	_, ok = c.Get(b)
+	assert.False(t, ok, "b использовался давнее всех и должен быть вытеснен")
+	_, ok = c.Get(a)
+	assert.True(t, ok)
+	_, ok = c.Get(d)
+	assert.True(t, ok)
+
+	s := c.Stats()
+	assert.Equal(t, 2, s.Entries)
+	assert.Equal(t, int64(1), s.Evictions)
+	assert.Equal(t, int64(3), s.Hits)
+	assert.Equal(t, int64(1), s.Misses)
+}
+
+func TestLRU_ByteBudget(t *testing.T) {
+	small := &models.Order{TrackNumber: "x"}
+	budget := orderSize(small)*2 + orderSize(small)/2
+	c := New(0, budget, 0, 0)
+
+	ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	for _, id := range ids {
+		c.Set(id, small)
+	}
+
+	s := c.Stats()
+	assert.Equal(t, 2, s.Entries)
+	assert.LessOrEqual(t, s.Bytes, budget)
+	assert.Equal(t, int64(1), s.Evictions)
+
+	large := &models.Order{Items: make([]models.Item, 1000)}
+	c.Set(uuid.New(), large)
+	assert.Equal(t, 2, c.Stats().Entries, "заказ больше всего бюджета не кэшируется")
+
+	c.Set(ids[2], large)
+	_, ok := c.Get(ids[2])
+	assert.False(t, ok, "старая версия заказа удаляется, если новая не влезает")
+	assert.Equal(t, 1, c.Stats().Entries)
+}
+
+func TestLRU_TTL(t *testing.T) {
+	now := time.Date(2025, 8, 25, 12, 0, 0, 0, time.UTC)
+	c := New(10, 0, time.Minute, 0)
+	c.now = func() time.Time { return now }
+
+	def, short, forever := uuid.New(), uuid.New(), uuid.New()
+	c.Set(def, &models.Order{})
+	c.SetWithTTL(short, &models.Order{}, time.Second)
+	c.SetWithTTL(forever, &models.Order{}, 0)
+
+	now = now.Add(2 * time.Second)
+	_, ok := c.Get(short)
+	assert.False(t, ok)
+	_, ok = c.Get(def)
+	assert.True(t, ok)
+
+	now = now.Add(time.Hour)
+	_, ok = c.Get(def)
+	assert.False(t, ok)
+	_, ok = c.Get(forever)
+	assert.True(t, ok)
+
+	s := c.Stats()
+	assert.Equal(t, int64(2), s.Expirations)
+	assert.Equal(t, 1, s.Entries)
+}
+
+func TestLRU_DeleteAndFlush(t *testing.T) {
+	c := New(10, 0, 0, 0)
+
+	a, b := uuid.New(), uuid.New()
+	c.Set(a, &models.Order{})
+	c.Set(b, &models.Order{})
+
+	c.Delete(a)
+	_, ok := c.Get(a)
+	assert.False(t, ok)
+	assert.Equal(t, orderSize(&models.Order{}), c.Stats().Bytes)
+
+	c.Flush()
+	assert.Equal(t, Stats{Misses: 1}, c.Stats())
+}
+
+func TestLRU_NegativeEntries(t *testing.T) {
+	now := time.Date(2025, 8, 25, 12, 0, 0, 0, time.UTC)
+	c := New(10, 0, time.Hour, 5*time.Second)
+	c.now = func() time.Time { return now }
+
+	missing := uuid.New()
+	c.SetNotFound(missing)
+
+	order, ok := c.Get(missing)
+	assert.True(t, ok)
+	assert.Nil(t, order)
+	assert.Equal(t, int64(1), c.Stats().NegativeHits)
+
+	now = now.Add(6 * time.Second)
+	_, ok = c.Get(missing)
+	assert.False(t, ok, "отрицательная запись живет negativeTTL, а не общий ttl")
+
+	c.SetNotFound(missing)
+	c.Delete(missing)
+	_, ok = c.Get(missing)
+	assert.False(t, ok)
+
+	disabled := New(10, 0, time.Hour, 0)
+	disabled.SetNotFound(missing)
+	assert.Equal(t, 0, disabled.Stats().Entries)
+}
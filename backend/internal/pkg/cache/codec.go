@@ -0,0 +1,55 @@
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/klauspost/compress/snappy"
+
+	"github.com/avraam311/order-service/backend/internal/models"
+)
+
+const (
+	kindNotFound byte = 0
+	kindOrder    byte = 1
+)
+
+var errUnknownEncoding = errors.New("неизвестный формат записи кэша")
+
+func encodeOrder(order *models.Order) ([]byte, error) {
+	if order == nil {
+		return []byte{kindNotFound}, nil
+	}
+
+	raw, err := json.Marshal(order)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte{kindOrder}, snappy.Encode(nil, raw)...), nil
+}
+
+func decodeOrder(b []byte) (*models.Order, error) {
+	if len(b) == 0 {
+		return nil, errUnknownEncoding
+	}
+
+	switch b[0] {
+	case kindNotFound:
+		return nil, nil
+	case kindOrder:
+		raw, err := snappy.Decode(nil, b[1:])
+		if err != nil {
+			return nil, err
+		}
+
+		var order models.Order
+		if err = json.Unmarshal(raw, &order); err != nil {
+			return nil, err
+		}
+
+		return &order, nil
+	default:
+		return nil, errUnknownEncoding
+	}
+}
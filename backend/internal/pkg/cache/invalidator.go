@@ -0,0 +1,80 @@
+package cache
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type deleter interface {
+	Delete(orderID uuid.UUID)
+}
+
+// DeleteFunc позволяет передать в Invalidator функцию вместо кэша.
+type DeleteFunc func(orderID uuid.UUID)
+
+func (f DeleteFunc) Delete(orderID uuid.UUID) {
+	f(orderID)
+}
+
+type PropagationStats struct {
+	Count int64         `json:"count"`
+	Last  time.Duration `json:"last"`
+	Avg   time.Duration `json:"avg"`
+	Max   time.Duration `json:"max"`
+}
+
+type Invalidator struct {
+	cache  deleter
+	logger *zap.Logger
+	now    func() time.Time
+
+	mu    sync.Mutex
+	count int64
+	last  time.Duration
+	total time.Duration
+	max   time.Duration
+}
+
+func NewInvalidator(c deleter, l *zap.Logger) *Invalidator {
+	return &Invalidator{
+		cache:  c,
+		logger: l,
+		now:    time.Now,
+	}
+}
+
+func (i *Invalidator) Invalidate(orderID uuid.UUID, changedAt time.Time) {
+	i.cache.Delete(orderID)
+
+	latency := i.now().Sub(changedAt)
+	if latency < 0 {
+		latency = 0
+	}
+
+	i.mu.Lock()
+	i.count++
+	i.last = latency
+	i.total += latency
+	i.max = max(i.max, latency)
+	i.mu.Unlock()
+
+	i.logger.Debug("заказ удален из кэша после изменения",
+		zap.String("order_uid", orderID.String()),
+		zap.Duration("propagation_latency", latency),
+	)
+}
+
+func (i *Invalidator) Stats() PropagationStats {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	s := PropagationStats{Count: i.count, Last: i.last, Max: i.max}
+	if i.count > 0 {
+		s.Avg = i.total / time.Duration(i.count)
+	}
+
+	return s
+}
@@ -0,0 +1,38 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type fakeDeleter struct {
+	deleted []uuid.UUID
+}
+
+func (f *fakeDeleter) Delete(orderID uuid.UUID) {
+	f.deleted = append(f.deleted, orderID)
+}
+
+func TestInvalidator_Invalidate(t *testing.T) {
+	now := time.Date(2025, 8, 20, 12, 0, 0, 0, time.UTC)
+	c := &fakeDeleter{}
+	inv := NewInvalidator(c, zap.NewNop())
+	inv.now = func() time.Time { return now }
+
+	first, second := uuid.New(), uuid.New()
+	inv.Invalidate(first, now.Add(-10*time.Millisecond))
+	inv.Invalidate(second, now.Add(-30*time.Millisecond))
+	inv.Invalidate(second, now.Add(time.Second))
+
+	assert.Equal(t, []uuid.UUID{first, second, second}, c.deleted)
+	assert.Equal(t, PropagationStats{
+		Count: 3,
+		Last:  0,
+		Avg:   40 * time.Millisecond / 3,
+		Max:   30 * time.Millisecond,
+	}, inv.Stats())
+}
@@ -0,0 +1,41 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+)
+
+type orderRepository interface {
+	GetLastOrders(ctx context.Context, limit int) ([]models.Order, error)
+}
+
+type setter interface {
+	Set(orderID uuid.UUID, order *models.Order)
+}
+
+func Preload(ctx context.Context, c setter, repo orderRepository, limit int, l *zap.Logger) error {
+	orders, err := repo.GetLastOrders(ctx, limit)
+	if err != nil {
+		l.Error("ошибка загрузки кэша", zap.Error(err))
+		return fmt.Errorf("backend/internal/pkg/cache/preload.go, ошибка загрузки кэша: %w", apperrors.ErrCachePreload)
+	}
+
+	if len(orders) == 0 {
+		l.Info("нет заказов для загрузки в кэш")
+		return nil
+	}
+
+	for _, order := range orders {
+		o := order
+		c.Set(order.OrderID, &o)
+	}
+
+	l.Info("кэш загружен успешно", zap.Int("orders_count", len(orders)))
+	return nil
+}
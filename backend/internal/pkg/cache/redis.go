@@ -0,0 +1,117 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/avraam311/order-service/backend/internal/models"
+)
+
+const redisTimeout = 200 * time.Millisecond
+
+type Redis struct {
+	client      redis.UniversalClient
+	logger      *zap.Logger
+	prefix      string
+	ttl         time.Duration
+	negativeTTL time.Duration
+}
+
+func NewRedis(client redis.UniversalClient, prefix string, ttl, negativeTTL time.Duration, l *zap.Logger) *Redis {
+	return &Redis{
+		client:      client,
+		logger:      l,
+		prefix:      prefix,
+		ttl:         ttl,
+		negativeTTL: negativeTTL,
+	}
+}
+
+func (r *Redis) key(orderID uuid.UUID) string {
+	return r.prefix + orderID.String()
+}
+
+func (r *Redis) Get(orderID uuid.UUID) (*models.Order, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	b, err := r.client.Get(ctx, r.key(orderID)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false
+	}
+	if err != nil {
+		r.logger.Warn("ошибка чтения из redis", zap.String("order_uid", orderID.String()), zap.Error(err))
+		return nil, false
+	}
+
+	order, err := decodeOrder(b)
+	if err != nil {
+		r.logger.Warn("ошибка декодирования заказа из redis", zap.String("order_uid", orderID.String()), zap.Error(err))
+		return nil, false
+	}
+
+	return order, true
+}
+
+func (r *Redis) Set(orderID uuid.UUID, order *models.Order) {
+	r.set(orderID, order, r.ttl)
+}
+
+func (r *Redis) SetNotFound(orderID uuid.UUID) {
+	if r.negativeTTL <= 0 {
+		return
+	}
+
+	r.set(orderID, nil, r.negativeTTL)
+}
+
+func (r *Redis) set(orderID uuid.UUID, order *models.Order, ttl time.Duration) {
+	b, err := encodeOrder(order)
+	if err != nil {
+		r.logger.Warn("ошибка кодирования заказа для redis", zap.String("order_uid", orderID.String()), zap.Error(err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	if err = r.client.Set(ctx, r.key(orderID), b, ttl).Err(); err != nil {
+		r.logger.Warn("ошибка записи в redis", zap.String("order_uid", orderID.String()), zap.Error(err))
+	}
+}
+
+func (r *Redis) Delete(orderID uuid.UUID) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	if err := r.client.Del(ctx, r.key(orderID)).Err(); err != nil {
+		r.logger.Warn("ошибка удаления из redis", zap.String("order_uid", orderID.String()), zap.Error(err))
+	}
+}
+
+// FlushLocal ничего не делает: redis общий для всех экземпляров, и очистка
+// после переподключения одного из них сбросила бы кэш у всех. Записи,
+// пропущенные за время без соединения, истекают по TTL.
+func (r *Redis) FlushLocal() {}
+
+func (r *Redis) Flush() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*redisTimeout)
+	defer cancel()
+
+	iter := r.client.Scan(ctx, 0, r.prefix+"*", 1000).Iterator()
+	for iter.Next(ctx) {
+		if err := r.client.Del(ctx, iter.Val()).Err(); err != nil {
+			r.logger.Warn("ошибка очистки redis", zap.Error(err))
+			return
+		}
+	}
+
+	if err := iter.Err(); err != nil {
+		r.logger.Warn("ошибка очистки redis", zap.Error(err))
+	}
+}
@@ -0,0 +1,124 @@
+package cache
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/models/modeltest"
+)
+
+func newTestRedis(t *testing.T, ttl, negativeTTL time.Duration) (*Redis, *miniredis.Miniredis) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	return NewRedis(client, "order:", ttl, negativeTTL, zap.NewNop()), mr
+}
+
+func testOrder(t testing.TB, orderID uuid.UUID) *models.Order {
+	o := modeltest.Order(t)
+	o.OrderID = orderID
+	o.Payment.Transaction = orderID.String()
+	o.Status = models.StatusPaid
+
+	return o
+}
+
+func TestRedis_SetGet(t *testing.T) {
+	c, mr := newTestRedis(t, time.Minute, 10*time.Second)
+
+	orderID := uuid.New()
+	want := testOrder(t, orderID)
+	c.Set(orderID, want)
+
+	got, ok := c.Get(orderID)
+	require.True(t, ok)
+	assert.Equal(t, want, got)
+	assert.Equal(t, time.Minute, mr.TTL("order:"+orderID.String()))
+
+	mr.FastForward(2 * time.Minute)
+	_, ok = c.Get(orderID)
+	assert.False(t, ok)
+}
+
+func TestRedis_NotFound(t *testing.T) {
+	c, mr := newTestRedis(t, time.Minute, 10*time.Second)
+
+	missing := uuid.New()
+	c.SetNotFound(missing)
+
+	order, ok := c.Get(missing)
+	assert.True(t, ok)
+	assert.Nil(t, order)
+	assert.Equal(t, 10*time.Second, mr.TTL("order:"+missing.String()))
+
+	c.Delete(missing)
+	_, ok = c.Get(missing)
+	assert.False(t, ok)
+}
+
+func TestRedis_Flush(t *testing.T) {
+	c, mr := newTestRedis(t, time.Minute, 0)
+
+	for range 3 {
+		id := uuid.New()
+		c.Set(id, testOrder(t, id))
+	}
+	require.NoError(t, mr.Set("other:key", "value"))
+
+	c.FlushLocal()
+	assert.Len(t, mr.Keys(), 4, "общий redis не очищается при переподключении одного экземпляра")
+
+	c.Flush()
+
+	assert.Equal(t, []string{"other:key"}, mr.Keys())
+}
+
+func TestRedis_CorruptValueIsMiss(t *testing.T) {
+	c, mr := newTestRedis(t, time.Minute, 0)
+
+	orderID := uuid.New()
+	require.NoError(t, mr.Set("order:"+orderID.String(), "\x07garbage"))
+
+	_, ok := c.Get(orderID)
+	assert.False(t, ok)
+}
+
+func TestRedis_Unavailable(t *testing.T) {
+	c, mr := newTestRedis(t, time.Minute, 0)
+	mr.Close()
+
+	orderID := uuid.New()
+	c.Set(orderID, testOrder(t, orderID))
+	_, ok := c.Get(orderID)
+	assert.False(t, ok, "недоступный redis — промах, а не ошибка запроса")
+}
+
+func TestEncodeOrder_Compact(t *testing.T) {
+	order := testOrder(t, uuid.New())
+	for range 50 {
+		order.Items = append(order.Items, order.Items[0])
+	}
+
+	raw, err := json.Marshal(order)
+	require.NoError(t, err)
+
+	b, err := encodeOrder(order)
+	require.NoError(t, err)
+	assert.Less(t, len(b), len(raw)/2)
+
+	got, err := decodeOrder(b)
+	require.NoError(t, err)
+	assert.Equal(t, order, got)
+}
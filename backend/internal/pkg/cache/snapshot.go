@@ -0,0 +1,182 @@
+package cache
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/klauspost/compress/snappy"
+
+	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+)
+
+const (
+	snapshotMagic   = "OCSN"
+	snapshotVersion = uint16(1)
+	// magic, версия, время создания, длина данных
+	snapshotHeaderSize = 4 + 2 + 8 + 8
+)
+
+var (
+	errSnapshotStale   = errors.New("снимок кэша устарел")
+	errSnapshotCorrupt = errors.New("снимок кэша поврежден")
+)
+
+type snapshotEntry struct {
+	Order     *models.Order `json:"order"`
+	ExpiresAt time.Time     `json:"expires_at,omitzero"`
+}
+
+func (c *LRU) SaveSnapshot(path string) error {
+	entries := c.entries()
+
+	var buf bytes.Buffer
+	if err := writeSnapshot(&buf, entries, c.now()); err != nil {
+		return fmt.Errorf("backend/internal/pkg/cache/snapshot.go, запись снимка: %w: %w", apperrors.ErrCacheSnapshot, err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("backend/internal/pkg/cache/snapshot.go, запись снимка: %w: %w", apperrors.ErrCacheSnapshot, err)
+	}
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("backend/internal/pkg/cache/snapshot.go, запись снимка: %w: %w", apperrors.ErrCacheSnapshot, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("backend/internal/pkg/cache/snapshot.go, запись снимка: %w: %w", apperrors.ErrCacheSnapshot, err)
+	}
+
+	return nil
+}
+
+func (c *LRU) LoadSnapshot(path string, maxAge time.Duration) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, fmt.Errorf("backend/internal/pkg/cache/snapshot.go, чтение снимка: %w: %w", apperrors.ErrCacheSnapshot, err)
+	}
+	defer f.Close()
+
+	now := c.now()
+	entries, err := readSnapshot(f, now, maxAge)
+	if err != nil {
+		return 0, fmt.Errorf("backend/internal/pkg/cache/snapshot.go, чтение снимка: %w: %w", apperrors.ErrCacheSnapshot, err)
+	}
+
+	loaded := 0
+	for _, e := range entries {
+		ttl := time.Duration(0)
+		if !e.ExpiresAt.IsZero() {
+			ttl = e.ExpiresAt.Sub(now)
+			if ttl <= 0 {
+				continue
+			}
+		}
+
+		c.SetWithTTL(e.Order.OrderID, e.Order, ttl)
+		loaded++
+	}
+
+	return loaded, nil
+}
+
+func (c *LRU) entries() []snapshotEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries := make([]snapshotEntry, 0, c.ll.Len())
+	for el := c.ll.Back(); el != nil; el = el.Prev() {
+		e := el.Value.(*entry)
+		if e.order == nil {
+			continue
+		}
+
+		entries = append(entries, snapshotEntry{Order: e.order, ExpiresAt: e.expiresAt})
+	}
+
+	return entries
+}
+
+func writeSnapshot(w io.Writer, entries []snapshotEntry, createdAt time.Time) error {
+	raw, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	payload := snappy.Encode(nil, raw)
+
+	header := make([]byte, snapshotHeaderSize)
+	copy(header, snapshotMagic)
+	binary.BigEndian.PutUint16(header[4:], snapshotVersion)
+	binary.BigEndian.PutUint64(header[6:], uint64(createdAt.UnixNano()))
+	binary.BigEndian.PutUint64(header[14:], uint64(len(payload)))
+
+	sum := crc32.NewIEEE()
+	sum.Write(header)
+	sum.Write(payload)
+
+	for _, b := range [][]byte{header, payload, binary.BigEndian.AppendUint32(nil, sum.Sum32())} {
+		if _, err = w.Write(b); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func readSnapshot(r io.Reader, now time.Time, maxAge time.Duration) ([]snapshotEntry, error) {
+	header := make([]byte, snapshotHeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("%w: %w", errSnapshotCorrupt, err)
+	}
+
+	if string(header[:4]) != snapshotMagic {
+		return nil, fmt.Errorf("%w: неизвестный формат", errSnapshotCorrupt)
+	}
+
+	if v := binary.BigEndian.Uint16(header[4:]); v != snapshotVersion {
+		return nil, fmt.Errorf("%w: версия %d не поддерживается", errSnapshotCorrupt, v)
+	}
+
+	createdAt := time.Unix(0, int64(binary.BigEndian.Uint64(header[6:])))
+	if maxAge > 0 && now.Sub(createdAt) > maxAge {
+		return nil, fmt.Errorf("%w: создан %s", errSnapshotStale, createdAt.Format(time.RFC3339))
+	}
+
+	size := binary.BigEndian.Uint64(header[14:])
+	rest, err := io.ReadAll(io.LimitReader(r, int64(size)+5))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errSnapshotCorrupt, err)
+	}
+	if uint64(len(rest)) != size+4 {
+		return nil, fmt.Errorf("%w: неверная длина", errSnapshotCorrupt)
+	}
+
+	payload, trailer := rest[:size], rest[size:]
+
+	sum := crc32.NewIEEE()
+	sum.Write(header)
+	sum.Write(payload)
+	if sum.Sum32() != binary.BigEndian.Uint32(trailer) {
+		return nil, fmt.Errorf("%w: контрольная сумма не совпадает", errSnapshotCorrupt)
+	}
+
+	raw, err := snappy.Decode(nil, payload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errSnapshotCorrupt, err)
+	}
+
+	var entries []snapshotEntry
+	if err = json.Unmarshal(raw, &entries); err != nil {
+		return nil, fmt.Errorf("%w: %w", errSnapshotCorrupt, err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,121 @@
+package cache
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+)
+
+func TestLRU_SnapshotRoundTrip(t *testing.T) {
+	now := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	path := filepath.Join(t.TempDir(), "cache.snapshot")
+
+	src := New(10, 0, time.Hour, time.Minute)
+	src.now = func() time.Time { return now }
+
+	old, recent, short := uuid.New(), uuid.New(), uuid.New()
+	src.Set(old, testOrder(t, old))
+	src.SetWithTTL(short, testOrder(t, short), time.Minute)
+	src.Set(recent, testOrder(t, recent))
+	src.SetNotFound(uuid.New())
+
+	require.NoError(t, src.SaveSnapshot(path))
+
+	dst := New(2, 0, time.Hour, time.Minute)
+	dst.now = func() time.Time { return now.Add(2 * time.Minute) }
+
+	loaded, err := dst.LoadSnapshot(path, 10*time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, 2, loaded, "истекшие и отрицательные записи не загружаются")
+
+	got, ok := dst.Get(recent)
+	require.True(t, ok)
+	assert.Equal(t, testOrder(t, recent), got)
+	_, ok = dst.Get(old)
+	assert.True(t, ok)
+	_, ok = dst.Get(short)
+	assert.False(t, ok)
+}
+
+func TestLRU_LoadSnapshotRejected(t *testing.T) {
+	now := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	orderID := uuid.New()
+
+	var valid bytes.Buffer
+	require.NoError(t, writeSnapshot(&valid, []snapshotEntry{{Order: testOrder(t, orderID)}}, now))
+
+	corrupted := bytes.Clone(valid.Bytes())
+	corrupted[snapshotHeaderSize+3] ^= 0xff
+
+	wrongVersion := bytes.Clone(valid.Bytes())
+	wrongVersion[5] = 99
+
+	tests := []struct {
+		name    string
+		data    []byte
+		now     time.Time
+		wantErr error
+	}{
+		{
+			name:    "снимок устарел",
+			data:    valid.Bytes(),
+			now:     now.Add(time.Hour),
+			wantErr: errSnapshotStale,
+		},
+		{
+			name:    "неверная контрольная сумма",
+			data:    corrupted,
+			now:     now,
+			wantErr: errSnapshotCorrupt,
+		},
+		{
+			name:    "неизвестная версия",
+			data:    wrongVersion,
+			now:     now,
+			wantErr: errSnapshotCorrupt,
+		},
+		{
+			name:    "обрезанный файл",
+			data:    valid.Bytes()[:valid.Len()-2],
+			now:     now,
+			wantErr: errSnapshotCorrupt,
+		},
+		{
+			name:    "не снимок",
+			data:    []byte("order_uid,track_number\n"),
+			now:     now,
+			wantErr: errSnapshotCorrupt,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "cache.snapshot")
+			require.NoError(t, os.WriteFile(path, tt.data, 0o644))
+
+			c := New(10, 0, time.Hour, 0)
+			c.now = func() time.Time { return tt.now }
+
+			loaded, err := c.LoadSnapshot(path, 10*time.Minute)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.ErrorIs(t, err, apperrors.ErrCacheSnapshot)
+			assert.Zero(t, loaded)
+			assert.Zero(t, c.Stats().Entries)
+		})
+	}
+}
+
+func TestLRU_LoadSnapshotMissingFile(t *testing.T) {
+	c := New(10, 0, time.Hour, 0)
+
+	_, err := c.LoadSnapshot(filepath.Join(t.TempDir(), "missing"), time.Minute)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
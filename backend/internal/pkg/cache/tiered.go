@@ -0,0 +1,91 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/avraam311/order-service/backend/internal/models"
+)
+
+const (
+	BackendMemory = "memory"
+	BackendRedis  = "redis"
+	BackendTiered = "tiered"
+)
+
+type store interface {
+	Get(orderID uuid.UUID) (*models.Order, bool)
+	Set(orderID uuid.UUID, order *models.Order)
+	SetNotFound(orderID uuid.UUID)
+	Delete(orderID uuid.UUID)
+	Flush()
+}
+
+type Tiered struct {
+	l1 *LRU
+	l2 store
+}
+
+func NewTiered(l1 *LRU, l2 store) *Tiered {
+	return &Tiered{
+		l1: l1,
+		l2: l2,
+	}
+}
+
+func (t *Tiered) Get(orderID uuid.UUID) (*models.Order, bool) {
+	if order, ok := t.l1.Get(orderID); ok {
+		return order, true
+	}
+
+	order, ok := t.l2.Get(orderID)
+	if !ok {
+		return nil, false
+	}
+
+	if order == nil {
+		t.l1.SetNotFound(orderID)
+	} else {
+		t.l1.Set(orderID, order)
+	}
+
+	return order, true
+}
+
+func (t *Tiered) Set(orderID uuid.UUID, order *models.Order) {
+	t.l2.Set(orderID, order)
+	t.l1.Set(orderID, order)
+}
+
+func (t *Tiered) SetNotFound(orderID uuid.UUID) {
+	t.l2.SetNotFound(orderID)
+	t.l1.SetNotFound(orderID)
+}
+
+func (t *Tiered) Delete(orderID uuid.UUID) {
+	t.l2.Delete(orderID)
+	t.l1.Delete(orderID)
+}
+
+func (t *Tiered) Flush() {
+	t.l2.Flush()
+	t.l1.Flush()
+}
+
+// FlushLocal очищает только L1 этого экземпляра, общий L2 не трогает.
+func (t *Tiered) FlushLocal() {
+	t.l1.Flush()
+}
+
+func (t *Tiered) Stats() Stats {
+	return t.l1.Stats()
+}
+
+func (t *Tiered) SaveSnapshot(path string) error {
+	return t.l1.SaveSnapshot(path)
+}
+
+func (t *Tiered) LoadSnapshot(path string, maxAge time.Duration) (int, error) {
+	return t.l1.LoadSnapshot(path, maxAge)
+}
@@ -0,0 +1,53 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTiered(t *testing.T) {
+	l2, mr := newTestRedis(t, time.Minute, 10*time.Second)
+	replicaA := NewTiered(New(10, 0, time.Minute, 10*time.Second), l2)
+	replicaB := NewTiered(New(10, 0, time.Minute, 10*time.Second), l2)
+
+	orderID := uuid.New()
+	replicaA.Set(orderID, testOrder(t, orderID))
+
+	got, ok := replicaB.Get(orderID)
+	require.True(t, ok, "вторая реплика получает заказ из общего L2")
+	assert.Equal(t, orderID, got.OrderID)
+	assert.Equal(t, 1, replicaB.Stats().Entries, "попадание в L2 заполняет L1")
+
+	mr.FlushAll()
+	_, ok = replicaB.Get(orderID)
+	assert.True(t, ok, "повторное чтение обслуживается из L1")
+
+	replicaB.Delete(orderID)
+	_, ok = replicaB.Get(orderID)
+	assert.False(t, ok)
+
+	missing := uuid.New()
+	replicaA.SetNotFound(missing)
+	order, ok := replicaB.Get(missing)
+	assert.True(t, ok)
+	assert.Nil(t, order)
+}
+
+func TestTiered_FlushLocal(t *testing.T) {
+	l2, _ := newTestRedis(t, time.Minute, 10*time.Second)
+	replicaA := NewTiered(New(10, 0, time.Minute, 10*time.Second), l2)
+	replicaB := NewTiered(New(10, 0, time.Minute, 10*time.Second), l2)
+
+	orderID := uuid.New()
+	replicaA.Set(orderID, testOrder(t, orderID))
+
+	replicaA.FlushLocal()
+	assert.Equal(t, 0, replicaA.Stats().Entries, "L1 очищен")
+
+	_, ok := replicaB.Get(orderID)
+	assert.True(t, ok, "общий L2 не очищается")
+}
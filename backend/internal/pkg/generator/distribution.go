@@ -0,0 +1,92 @@
+package generator
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+)
+
+// Distribution задает число товаров в заказе, всегда не меньше 1.
+type Distribution interface {
+	Sample(r *rand.Rand) int
+	String() string
+}
+
+type Fixed int
+
+func (d Fixed) Sample(*rand.Rand) int {
+	return int(d)
+}
+
+func (d Fixed) String() string {
+	return "fixed:" + strconv.Itoa(int(d))
+}
+
+type Uniform struct {
+	Min int
+	Max int
+}
+
+func (d Uniform) Sample(r *rand.Rand) int {
+	return d.Min + r.IntN(d.Max-d.Min+1)
+}
+
+func (d Uniform) String() string {
+	return fmt.Sprintf("uniform:%d-%d", d.Min, d.Max)
+}
+
+// Poisson дает 1 + Poisson(Mean-1), так что среднее равно Mean, а пустых
+// заказов не бывает.
+type Poisson struct {
+	Mean float64
+}
+
+func (d Poisson) Sample(r *rand.Rand) int {
+	limit := math.Exp(-(d.Mean - 1))
+	n := 0
+	for p := r.Float64(); p > limit; p *= r.Float64() {
+		n++
+	}
+
+	return n + 1
+}
+
+func (d Poisson) String() string {
+	return "poisson:" + strconv.FormatFloat(d.Mean, 'g', -1, 64)
+}
+
+// ParseDistribution разбирает распределение вида "fixed:3", "uniform:1-5"
+// или "poisson:2.5".
+func ParseDistribution(s string) (Distribution, error) {
+	kind, param, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok {
+		return nil, fmt.Errorf("распределение %q: %w", s, ErrInvalidConfig)
+	}
+
+	switch kind {
+	case "fixed":
+		n, err := strconv.Atoi(param)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("распределение %q: %w", s, ErrInvalidConfig)
+		}
+		return Fixed(n), nil
+	case "uniform":
+		lo, hi, ok := strings.Cut(param, "-")
+		minItems, errMin := strconv.Atoi(lo)
+		maxItems, errMax := strconv.Atoi(hi)
+		if !ok || errMin != nil || errMax != nil || minItems < 1 || maxItems < minItems {
+			return nil, fmt.Errorf("распределение %q: %w", s, ErrInvalidConfig)
+		}
+		return Uniform{Min: minItems, Max: maxItems}, nil
+	case "poisson":
+		mean, err := strconv.ParseFloat(param, 64)
+		if err != nil || mean < 1 || mean > 100 {
+			return nil, fmt.Errorf("распределение %q: %w", s, ErrInvalidConfig)
+		}
+		return Poisson{Mean: mean}, nil
+	default:
+		return nil, fmt.Errorf("распределение %q: %w", s, ErrInvalidConfig)
+	}
+}
@@ -0,0 +1,71 @@
+package generator
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDistribution(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Distribution
+		wantErr bool
+	}{
+		{in: "fixed:3", want: Fixed(3)},
+		{in: "uniform:1-5", want: Uniform{Min: 1, Max: 5}},
+		{in: "poisson:2.5", want: Poisson{Mean: 2.5}},
+		{in: "fixed:0", wantErr: true},
+		{in: "uniform:5-1", wantErr: true},
+		{in: "uniform:3", wantErr: true},
+		{in: "poisson:0.5", wantErr: true},
+		{in: "normal:3", wantErr: true},
+		{in: "3", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseDistribution(tt.in)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidConfig)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.in, got.String())
+		})
+	}
+}
+
+func TestDistribution_Sample(t *testing.T) {
+	tests := []struct {
+		name     string
+		d        Distribution
+		min, max int
+		mean     float64
+	}{
+		{name: "фиксированное", d: Fixed(4), min: 4, max: 4, mean: 4},
+		{name: "равномерное", d: Uniform{Min: 2, Max: 6}, min: 2, max: 6, mean: 4},
+		{name: "пуассон", d: Poisson{Mean: 3}, min: 1, max: 1 << 30, mean: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := rand.New(rand.NewPCG(1, 1))
+
+			const n = 20000
+			sum := 0
+			for range n {
+				v := tt.d.Sample(r)
+				require.GreaterOrEqual(t, v, tt.min)
+				require.LessOrEqual(t, v, tt.max)
+				sum += v
+			}
+
+			assert.InDelta(t, tt.mean, float64(sum)/n, 0.1)
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package generator
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/avraam311/order-service/backend/internal/models"
+)
+
+var ErrInvalidConfig = errors.New("некорректные параметры генератора")
+
+type Kind string
+
+const (
+	KindValid     Kind = "valid"
+	KindInvalid   Kind = "invalid"
+	KindMalformed Kind = "malformed"
+	KindDuplicate Kind = "duplicate"
+)
+
+// duplicatesWindow — сколько последних валидных заказов помнит генератор для
+// повторной отправки.
+const duplicatesWindow = 128
+
+type Config struct {
+	Items         Distribution
+	Locale        string
+	Currency      string
+	InvalidRate   float64
+	MalformedRate float64
+	DuplicateRate float64
+}
+
+type Sample struct {
+	Order *models.Order
+	Kind  Kind
+}
+
+// Generator создает случайные заказы. Не безопасен для конкурентного
+// использования.
+type Generator struct {
+	cfg    Config
+	locale localeData
+	rand   *rand.Rand
+	now    func() time.Time
+	recent []*models.Order
+	next   int
+}
+
+func New(cfg Config, seed uint64) (*Generator, error) {
+	locale, ok := locales[cfg.Locale]
+	if !ok {
+		return nil, fmt.Errorf("backend/internal/pkg/generator/generator.go, локаль %q: %w", cfg.Locale, ErrInvalidConfig)
+	}
+
+	if cfg.Currency == "" {
+		return nil, fmt.Errorf("backend/internal/pkg/generator/generator.go, пустая валюта: %w", ErrInvalidConfig)
+	}
+
+	rates := []float64{cfg.InvalidRate, cfg.MalformedRate, cfg.DuplicateRate}
+	total := 0.0
+	for _, r := range rates {
+		if r < 0 || r > 1 {
+			return nil, fmt.Errorf("backend/internal/pkg/generator/generator.go, доля %v: %w", r, ErrInvalidConfig)
+		}
+		total += r
+	}
+	if total > 1 {
+		return nil, fmt.Errorf("backend/internal/pkg/generator/generator.go, сумма долей %v больше 1: %w", total, ErrInvalidConfig)
+	}
+
+	if cfg.Items == nil {
+		cfg.Items = Fixed(1)
+	}
+	cfg.Currency = strings.ToUpper(cfg.Currency)
+
+	return &Generator{
+		cfg:    cfg,
+		locale: locale,
+		rand:   rand.New(rand.NewPCG(seed, seed)),
+		now:    time.Now,
+	}, nil
+}
+
+// Next возвращает очередной заказ. Невалидный заказ не проходит валидацию,
+// malformed нужно испортить после кодирования (Corrupt), дубликат — один из
+// недавно отправленных валидных заказов без изменений.
+func (g *Generator) Next() Sample {
+	p := g.rand.Float64()
+
+	switch {
+	case p < g.cfg.InvalidRate:
+		order := g.order()
+		g.breakOrder(order)
+		return Sample{Order: order, Kind: KindInvalid}
+	case p < g.cfg.InvalidRate+g.cfg.MalformedRate:
+		return Sample{Order: g.order(), Kind: KindMalformed}
+	case p < g.cfg.InvalidRate+g.cfg.MalformedRate+g.cfg.DuplicateRate && len(g.recent) > 0:
+		return Sample{Order: g.recent[g.rand.IntN(len(g.recent))], Kind: KindDuplicate}
+	}
+
+	order := g.order()
+	g.remember(order)
+
+	return Sample{Order: order, Kind: KindValid}
+}
+
+// Corrupt обрезает закодированное сообщение, чтобы его нельзя было разобрать.
+func Corrupt(msg []byte) []byte {
+	return msg[:len(msg)/2]
+}
+
+func (g *Generator) remember(order *models.Order) {
+	if len(g.recent) < duplicatesWindow {
+		g.recent = append(g.recent, order)
+		return
+	}
+
+	g.recent[g.next] = order
+	g.next = (g.next + 1) % duplicatesWindow
+}
+
+func (g *Generator) order() *models.Order {
+	orderID := g.uuid()
+	trackNumber := "WBIL" + g.letters(10)
+	now := g.now()
+
+	female := g.rand.IntN(2)
+	firstName := pick(g.rand, g.locale.maleNames)
+	if female == 1 {
+		firstName = pick(g.rand, g.locale.femaleNames)
+	}
+	lastName := pick(g.rand, g.locale.lastNames)[female]
+	c := pick(g.rand, g.locale.cities)
+
+	items := make([]models.Item, g.cfg.Items.Sample(g.rand))
+	goodsTotal := 0
+	for i := range items {
+		price := 100 + g.rand.IntN(4900)
+		sale := pick(g.rand, sales)
+		items[i] = models.Item{
+			ChrtID:      1_000_000 + g.rand.IntN(9_000_000),
+			TrackNumber: trackNumber,
+			Price:       price,
+			RID:         g.hex(20) + "test",
+			Name:        pick(g.rand, g.locale.products),
+			Sale:        sale,
+			Size:        pick(g.rand, sizes),
+			TotalPrice:  price * (100 - sale) / 100,
+			NmID:        1_000_000 + g.rand.IntN(9_000_000),
+			Brand:       pick(g.rand, brands),
+			Status:      202,
+		}
+		goodsTotal += items[i].TotalPrice
+	}
+
+	deliveryCost := 200 + g.rand.IntN(1300)
+
+	return &models.Order{
+		OrderID:     orderID,
+		TrackNumber: trackNumber,
+		Entry:       "WBIL",
+		Delivery: models.Delivery{
+			Name:    firstName + " " + lastName,
+			Phone:   g.locale.phonePrefix + g.digits(10),
+			Zip:     c.zip + g.digits(3),
+			City:    c.name,
+			Address: fmt.Sprintf("%s %d", pick(g.rand, g.locale.streets), 1+g.rand.IntN(150)),
+			Region:  c.region,
+			Email:   fmt.Sprintf("user%s@%s", g.digits(6), pick(g.rand, g.locale.emailDomains)),
+		},
+		Payment: models.Payment{
+			Transaction:  orderID.String(),
+			Currency:     g.cfg.Currency,
+			Provider:     pick(g.rand, providers),
+			Amount:       goodsTotal + deliveryCost,
+			PaymentDT:    now.Unix(),
+			Bank:         pick(g.rand, g.locale.banks),
+			DeliveryCost: deliveryCost,
+			GoodsTotal:   goodsTotal,
+		},
+		Items:           items,
+		Locale:          g.cfg.Locale,
+		CustomerId:      "customer-" + g.digits(6),
+		DeliveryService: pick(g.rand, g.locale.deliveryServices),
+		Shardkey:        g.digits(1),
+		SmId:            1 + g.rand.IntN(999),
+		DateCreated:     now.UTC().Truncate(time.Second),
+		OofShard:        fmt.Sprint(1 + g.rand.IntN(2)),
+	}
+}
+
+// breakOrder портит одно обязательное поле так, чтобы заказ не прошел
+// валидацию.
+func (g *Generator) breakOrder(order *models.Order) {
+	switch g.rand.IntN(4) {
+	case 0:
+		order.Items = nil
+	case 1:
+		order.Delivery.Email = "not-an-email"
+	case 2:
+		order.TrackNumber = ""
+	default:
+		order.Payment.Transaction = ""
+	}
+}
+
+// uuid берет байты из генератора, чтобы при одном seed заказы повторялись.
+func (g *Generator) uuid() uuid.UUID {
+	var id uuid.UUID
+	binary.BigEndian.PutUint64(id[:8], g.rand.Uint64())
+	binary.BigEndian.PutUint64(id[8:], g.rand.Uint64())
+	id[6] = id[6]&0x0f | 0x40
+	id[8] = id[8]&0x3f | 0x80
+
+	return id
+}
+
+func (g *Generator) hex(n int) string {
+	const alphabet = "0123456789abcdef"
+
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = alphabet[g.rand.IntN(len(alphabet))]
+	}
+
+	return string(b)
+}
+
+func (g *Generator) letters(n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte('A' + g.rand.IntN(26))
+	}
+
+	return string(b)
+}
+
+func (g *Generator) digits(n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte('0' + g.rand.IntN(10))
+	}
+
+	return string(b)
+}
+
+func pick[T any](r *rand.Rand, values []T) T {
+	return values[r.IntN(len(values))]
+}
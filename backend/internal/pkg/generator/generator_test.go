@@ -0,0 +1,106 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avraam311/order-service/backend/internal/pkg/validator"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{name: "валидный конфиг", cfg: Config{Locale: "ru", Currency: "rub", InvalidRate: 0.1, DuplicateRate: 0.2}},
+		{name: "неизвестная локаль", cfg: Config{Locale: "fr", Currency: "EUR"}, wantErr: true},
+		{name: "пустая валюта", cfg: Config{Locale: "en"}, wantErr: true},
+		{name: "отрицательная доля", cfg: Config{Locale: "en", Currency: "USD", InvalidRate: -0.1}, wantErr: true},
+		{name: "сумма долей больше 1", cfg: Config{Locale: "en", Currency: "USD", InvalidRate: 0.6, MalformedRate: 0.5}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.cfg, 1)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidConfig)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestGenerator_Next(t *testing.T) {
+	v := validator.New()
+
+	g, err := New(Config{
+		Items:         Uniform{Min: 1, Max: 5},
+		Locale:        "ru",
+		Currency:      "RUB",
+		InvalidRate:   0.1,
+		MalformedRate: 0.05,
+		DuplicateRate: 0.1,
+	}, 42)
+	require.NoError(t, err)
+
+	const n = 5000
+	counts := make(map[Kind]int)
+	sent := make(map[string]bool)
+	for range n {
+		s := g.Next()
+		counts[s.Kind]++
+
+		switch s.Kind {
+		case KindInvalid:
+			assert.Error(t, v.Validate(s.Order))
+		case KindDuplicate:
+			assert.True(t, sent[s.Order.OrderID.String()], "дубликат уже отправлялся")
+		default:
+			require.NoError(t, v.Validate(s.Order))
+
+			goodsTotal := 0
+			for _, item := range s.Order.Items {
+				assert.Equal(t, s.Order.TrackNumber, item.TrackNumber)
+				goodsTotal += item.TotalPrice
+			}
+			assert.Equal(t, goodsTotal, s.Order.Payment.GoodsTotal)
+			assert.Equal(t, goodsTotal+s.Order.Payment.DeliveryCost, s.Order.Payment.Amount)
+			assert.Equal(t, "RUB", s.Order.Payment.Currency)
+			assert.Equal(t, "ru", s.Order.Locale)
+		}
+
+		if s.Kind == KindValid {
+			assert.False(t, sent[s.Order.OrderID.String()], "новый заказ уникален")
+			sent[s.Order.OrderID.String()] = true
+		}
+	}
+
+	assert.InDelta(t, 0.1, float64(counts[KindInvalid])/n, 0.02)
+	assert.InDelta(t, 0.05, float64(counts[KindMalformed])/n, 0.02)
+	assert.InDelta(t, 0.1, float64(counts[KindDuplicate])/n, 0.02)
+}
+
+func TestGenerator_Seed(t *testing.T) {
+	cfg := Config{Items: Fixed(2), Locale: "en", Currency: "USD"}
+
+	a, err := New(cfg, 7)
+	require.NoError(t, err)
+	b, err := New(cfg, 7)
+	require.NoError(t, err)
+
+	for range 10 {
+		x, y := a.Next().Order, b.Next().Order
+		assert.Equal(t, x.OrderID, y.OrderID)
+		assert.Equal(t, x.Items, y.Items)
+		assert.Equal(t, x.Delivery, y.Delivery)
+	}
+}
+
+func TestCorrupt(t *testing.T) {
+	assert.Equal(t, []byte(`{"orde`), Corrupt([]byte(`{"order_uid"}`)))
+}
@@ -0,0 +1,75 @@
+package generator
+
+type city struct {
+	name   string
+	region string
+	zip    string
+}
+
+// lastName хранит мужскую и женскую форму фамилии.
+type lastName [2]string
+
+type localeData struct {
+	maleNames        []string
+	femaleNames      []string
+	lastNames        []lastName
+	cities           []city
+	streets          []string
+	phonePrefix      string
+	emailDomains     []string
+	deliveryServices []string
+	banks            []string
+	products         []string
+}
+
+var locales = map[string]localeData{
+	"en": {
+		maleNames:   []string{"John", "Michael", "David", "James"},
+		femaleNames: []string{"Emily", "Sarah", "Olivia", "Emma"},
+		lastNames: []lastName{
+			{"Smith", "Smith"}, {"Johnson", "Johnson"}, {"Brown", "Brown"}, {"Taylor", "Taylor"},
+			{"Miller", "Miller"}, {"Wilson", "Wilson"}, {"Moore", "Moore"}, {"Clark", "Clark"},
+		},
+		cities: []city{
+			{"New York", "NY", "100"},
+			{"Los Angeles", "CA", "900"},
+			{"Chicago", "IL", "606"},
+			{"Houston", "TX", "770"},
+			{"Seattle", "WA", "981"},
+		},
+		streets:          []string{"Main St", "Oak Ave", "Maple Dr", "Sunset Blvd", "Park Ln"},
+		phonePrefix:      "+1",
+		emailDomains:     []string{"gmail.com", "yahoo.com", "outlook.com"},
+		deliveryServices: []string{"meest", "dhl", "ups", "fedex"},
+		banks:            []string{"alpha", "chase", "citi", "wells"},
+		products:         []string{"Mascaras", "Lipstick", "T-Shirt", "Sneakers", "Backpack", "Headphones", "Mug"},
+	},
+	"ru": {
+		maleNames:   []string{"Иван", "Дмитрий", "Алексей", "Сергей"},
+		femaleNames: []string{"Анна", "Мария", "Елена", "Ольга"},
+		lastNames: []lastName{
+			{"Иванов", "Иванова"}, {"Смирнов", "Смирнова"}, {"Кузнецов", "Кузнецова"}, {"Попов", "Попова"},
+			{"Соколов", "Соколова"}, {"Лебедев", "Лебедева"}, {"Козлов", "Козлова"}, {"Новиков", "Новикова"},
+		},
+		cities: []city{
+			{"Москва", "Москва", "101"},
+			{"Санкт-Петербург", "Санкт-Петербург", "190"},
+			{"Казань", "Татарстан", "420"},
+			{"Екатеринбург", "Свердловская область", "620"},
+			{"Новосибирск", "Новосибирская область", "630"},
+		},
+		streets:          []string{"ул. Ленина", "пр. Мира", "ул. Гагарина", "ул. Садовая", "Невский пр."},
+		phonePrefix:      "+7",
+		emailDomains:     []string{"mail.ru", "yandex.ru", "gmail.com"},
+		deliveryServices: []string{"cdek", "boxberry", "pochta", "meest"},
+		banks:            []string{"alpha", "sber", "tinkoff", "vtb"},
+		products:         []string{"Тушь для ресниц", "Помада", "Футболка", "Кроссовки", "Рюкзак", "Наушники", "Кружка"},
+	},
+}
+
+var (
+	brands    = []string{"Vivienne Sabo", "Nike", "Adidas", "Xiaomi", "Samsung", "Zara", "Ikea"}
+	sizes     = []string{"0", "S", "M", "L", "XL", "42"}
+	sales     = []int{0, 0, 0, 10, 20, 30, 50}
+	providers = []string{"wbpay", "applepay", "googlepay"}
+)
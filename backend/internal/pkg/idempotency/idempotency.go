@@ -0,0 +1,127 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+)
+
+type Response struct {
+	Status      int
+	ContentType string
+	Location    string
+	Body        []byte
+}
+
+type entry struct {
+	fingerprint string
+	done        bool
+	response    Response
+	expiresAt   time.Time
+}
+
+// Store хранит ключи в памяти одного экземпляра api. Ключи приходят от
+// клиентов, поэтому их число ограничено maxEntries: когда место кончается,
+// новые ключи отклоняются, а не вытесняют чужие.
+type Store struct {
+	mu         sync.Mutex
+	entries    map[string]*entry
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+}
+
+func NewStore(ttl time.Duration, maxEntries int) *Store {
+	return &Store{
+		entries:    make(map[string]*entry),
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		now:        time.Now,
+	}
+}
+
+// Run раз в interval удаляет истекшие ключи.
+func (s *Store) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.mu.Lock()
+			s.sweep(s.now())
+			s.mu.Unlock()
+		}
+	}
+}
+
+func (s *Store) Begin(key, fingerprint string) (*Response, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+
+	e, ok := s.entries[key]
+	if !ok || now.After(e.expiresAt) {
+		if !ok && s.full() {
+			s.sweep(now)
+			if s.full() {
+				return nil, apperrors.ErrIdempotencyStoreFull
+			}
+		}
+
+		s.entries[key] = &entry{
+			fingerprint: fingerprint,
+			expiresAt:   now.Add(s.ttl),
+		}
+		return nil, nil
+	}
+
+	if e.fingerprint != fingerprint {
+		return nil, apperrors.ErrIdempotencyKeyReused
+	}
+
+	if !e.done {
+		return nil, apperrors.ErrIdempotencyInProgress
+	}
+
+	resp := e.response
+	return &resp, nil
+}
+
+func (s *Store) Complete(key string, resp Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[key]
+	if !ok {
+		return
+	}
+
+	e.done = true
+	e.response = resp
+	e.expiresAt = s.now().Add(s.ttl)
+}
+
+func (s *Store) Abort(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+}
+
+func (s *Store) full() bool {
+	return s.maxEntries > 0 && len(s.entries) >= s.maxEntries
+}
+
+func (s *Store) sweep(now time.Time) {
+	for key, e := range s.entries {
+		if now.After(e.expiresAt) {
+			delete(s.entries, key)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package idempotency
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+)
+
+const ttl = time.Minute
+
+func newTestStore(now *time.Time) *Store {
+	s := NewStore(ttl, 0)
+	s.now = func() time.Time { return *now }
+
+	return s
+}
+
+func TestStore_Begin(t *testing.T) {
+	created := Response{Status: http.StatusCreated, ContentType: "application/json", Location: "/order/1", Body: []byte(`{"order_uid":"1"}`)}
+
+	tests := []struct {
+		name        string
+		prepare     func(s *Store, now *time.Time)
+		fingerprint string
+		want        *Response
+		wantErr     error
+	}{
+		{
+			name:        "новый ключ",
+			prepare:     func(*Store, *time.Time) {},
+			fingerprint: "a",
+		},
+		{
+			name: "запрос еще обрабатывается",
+			prepare: func(s *Store, _ *time.Time) {
+				_, _ = s.Begin("key", "a")
+			},
+			fingerprint: "a",
+			wantErr:     apperrors.ErrIdempotencyInProgress,
+		},
+		{
+			name: "сохраненный ответ",
+			prepare: func(s *Store, _ *time.Time) {
+				_, _ = s.Begin("key", "a")
+				s.Complete("key", created)
+			},
+			fingerprint: "a",
+			want:        &created,
+		},
+		{
+			name: "другое тело во время обработки",
+			prepare: func(s *Store, _ *time.Time) {
+				_, _ = s.Begin("key", "a")
+			},
+			fingerprint: "b",
+			wantErr:     apperrors.ErrIdempotencyKeyReused,
+		},
+		{
+			name: "другое тело после ответа",
+			prepare: func(s *Store, _ *time.Time) {
+				_, _ = s.Begin("key", "a")
+				s.Complete("key", created)
+			},
+			fingerprint: "b",
+			wantErr:     apperrors.ErrIdempotencyKeyReused,
+		},
+		{
+			name: "ответ истек",
+			prepare: func(s *Store, now *time.Time) {
+				_, _ = s.Begin("key", "a")
+				s.Complete("key", created)
+				*now = now.Add(ttl + time.Second)
+			},
+			fingerprint: "b",
+		},
+		{
+			name: "ответ еще не истек",
+			prepare: func(s *Store, now *time.Time) {
+				_, _ = s.Begin("key", "a")
+				*now = now.Add(ttl / 2)
+				s.Complete("key", created)
+				*now = now.Add(ttl - time.Second)
+			},
+			fingerprint: "a",
+			want:        &created,
+		},
+		{
+			name: "обработка отменена",
+			prepare: func(s *Store, _ *time.Time) {
+				_, _ = s.Begin("key", "a")
+				s.Abort("key")
+			},
+			fingerprint: "b",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Date(2025, 8, 20, 12, 0, 0, 0, time.UTC)
+			s := newTestStore(&now)
+			tt.prepare(s, &now)
+
+			got, err := s.Begin("key", tt.fingerprint)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestStore_CompleteUnknownKey(t *testing.T) {
+	now := time.Date(2025, 8, 20, 12, 0, 0, 0, time.UTC)
+	s := newTestStore(&now)
+
+	s.Complete("key", Response{Status: http.StatusCreated})
+
+	got, err := s.Begin("key", "a")
+	require.NoError(t, err)
+	assert.Nil(t, got, "ответ без Begin не сохраняется")
+}
+
+func TestStore_MaxEntries(t *testing.T) {
+	now := time.Date(2025, 8, 20, 12, 0, 0, 0, time.UTC)
+	s := newTestStore(&now)
+	s.maxEntries = 2
+
+	_, err := s.Begin("a", "a")
+	require.NoError(t, err)
+	s.Complete("a", Response{Status: http.StatusCreated})
+	_, err = s.Begin("b", "b")
+	require.NoError(t, err)
+
+	_, err = s.Begin("c", "c")
+	assert.ErrorIs(t, err, apperrors.ErrIdempotencyStoreFull, "новый ключ не вытесняет активные")
+
+	got, err := s.Begin("a", "a")
+	require.NoError(t, err)
+	assert.Equal(t, &Response{Status: http.StatusCreated}, got, "известный ключ работает и при заполненном хранилище")
+
+	now = now.Add(ttl + time.Second)
+	_, err = s.Begin("c", "c")
+	require.NoError(t, err, "истекшие ключи освобождают место")
+	assert.Len(t, s.entries, 1)
+	assert.Contains(t, s.entries, "c")
+}
+
+func TestStore_Run(t *testing.T) {
+	var mu sync.Mutex
+	now := time.Date(2025, 8, 20, 12, 0, 0, 0, time.UTC)
+	s := NewStore(ttl, 0)
+	s.now = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+
+	for _, key := range []string{"a", "b", "c"} {
+		_, err := s.Begin(key, key)
+		require.NoError(t, err)
+	}
+
+	mu.Lock()
+	now = now.Add(ttl + time.Second)
+	mu.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.Run(ctx, time.Millisecond)
+
+	assert.Eventually(t, func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return len(s.entries) == 0
+	}, time.Second, time.Millisecond)
+}
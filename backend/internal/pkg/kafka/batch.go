@@ -0,0 +1,155 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+)
+
+type batchHandler interface {
+	HandleBatch(ctx context.Context, msgs []kafka.Message) ([]error, error)
+}
+
+// defaultBatchLinger ограничивает ожидание пакета, если Linger не задан: иначе
+// на тихом топике неполный пакет ждал бы сообщений без конца.
+const defaultBatchLinger = 200 * time.Millisecond
+
+type BatchPolicy struct {
+	Size   int
+	Linger time.Duration
+}
+
+// ConsumeBatches читает до Size сообщений или ждет не дольше Linger после
+// первого, сохраняет пакет целиком и коммитит оффсеты всего пакета. Если пакет
+// не удалось сохранить, сообщения обрабатываются по одному. Пакет, прерванный
+// shutdown, не коммитится.
+func (c *Consumer) ConsumeBatches(ctx context.Context, wg *sync.WaitGroup, policy BatchPolicy) {
+	defer wg.Done()
+	defer c.Close()
+
+	c.start(ctx)
+
+	for {
+		batch, stopped := c.fetchBatch(ctx, policy)
+		if stopped {
+			if len(batch) > 0 {
+				c.logger.Info("неполный пакет не закоммичен и будет прочитан повторно",
+					zap.Int64("first_offset", batch[0].Offset),
+					zap.Int("size", len(batch)),
+				)
+			}
+			break
+		}
+
+		if !c.processBatch(ctx, batch) {
+			break
+		}
+	}
+
+	c.logger.Info("чтение сообщения завершено")
+}
+
+func (c *Consumer) fetchBatch(ctx context.Context, policy BatchPolicy) ([]kafka.Message, bool) {
+	size := max(policy.Size, 1)
+	batch := make([]kafka.Message, 0, size)
+
+	linger := policy.Linger
+	if linger <= 0 {
+		linger = defaultBatchLinger
+	}
+
+	fetchCtx := ctx
+	for len(batch) < size {
+		m, err := c.reader.FetchMessage(fetchCtx)
+		if err != nil {
+			if len(batch) > 0 && ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+				return batch, false
+			}
+
+			if c.fetchStopped(err) {
+				return batch, true
+			}
+			continue
+		}
+
+		batch = append(batch, m)
+		if len(batch) == 1 {
+			var cancel context.CancelFunc
+			fetchCtx, cancel = context.WithTimeout(ctx, linger)
+			defer cancel()
+		}
+	}
+
+	return batch, false
+}
+
+// batchRoute возвращает маршрут пакета, если все сообщения идут в один
+// обработчик и он умеет сохранять пакеты.
+func (c *Consumer) batchRoute(batch []kafka.Message) (*Route, batchHandler) {
+	rt := c.router.match(batch[0])
+	for _, m := range batch[1:] {
+		if c.router.match(m) != rt {
+			return nil, nil
+		}
+	}
+
+	bh, ok := rt.Handler.(batchHandler)
+	if !ok {
+		return nil, nil
+	}
+
+	return rt, bh
+}
+
+func (c *Consumer) processBatch(ctx context.Context, batch []kafka.Message) bool {
+	var results []error
+	if rt, bh := c.batchRoute(batch); bh != nil {
+		err := c.withRetry(ctx, rt.Retry, batch[0].Offset, func() error {
+			var err error
+			results, err = bh.HandleBatch(ctx, batch)
+			return err
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				c.logger.Warn("backend/internal/pkg/kafka/batch.go, обработка пакета прервана shutdown", zap.Error(err))
+				return false
+			}
+
+			c.logger.Warn("backend/internal/pkg/kafka/batch.go, пакет не сохранен, обработка по одному сообщению",
+				zap.Int64("first_offset", batch[0].Offset),
+				zap.Int("size", len(batch)),
+				zap.Error(err),
+			)
+			results = nil
+		}
+	}
+
+	for i, m := range batch {
+		var err error
+		if results != nil {
+			err = results[i]
+		}
+		if results == nil || apperrors.IsRetryable(err) {
+			err = c.handleWithRetry(ctx, m)
+		}
+
+		if !c.settle(ctx, m, err) {
+			c.commit(ctx, batch[:i]...)
+			return false
+		}
+	}
+
+	c.commit(ctx, batch...)
+	c.logger.Info("пакет сообщений обработан",
+		zap.Int64("first_offset", batch[0].Offset),
+		zap.Int("size", len(batch)),
+	)
+
+	return true
+}
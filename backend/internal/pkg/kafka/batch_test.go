@@ -0,0 +1,195 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+	"github.com/avraam311/order-service/backend/internal/pkg/kafka/kafkatest"
+)
+
+type stubBatchHandler struct {
+	stubHandler
+	batchErrs    []error
+	batchResults map[string]error
+	batches      [][]string
+}
+
+func (h *stubBatchHandler) HandleBatch(_ context.Context, msgs []kafka.Message) ([]error, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	keys := make([]string, len(msgs))
+	results := make([]error, len(msgs))
+	for i, m := range msgs {
+		keys[i] = string(m.Value)
+		results[i] = h.batchResults[keys[i]]
+	}
+
+	call := len(h.batches)
+	h.batches = append(h.batches, keys)
+	if call < len(h.batchErrs) && h.batchErrs[call] != nil {
+		return nil, h.batchErrs[call]
+	}
+
+	return results, nil
+}
+
+func TestConsumer_ConsumeBatches(t *testing.T) {
+	retryableErr := fmt.Errorf("ошибка создания заказов: %w: %w", apperrors.ErrTxCommit, apperrors.ErrDBConnection)
+	permanentErr := fmt.Errorf("%w: unexpected EOF", apperrors.ErrInvalidJSON)
+
+	tests := []struct {
+		name          string
+		policy        BatchPolicy
+		batchErrs     []error
+		batchResults  map[string]error
+		handlerErrs   map[string][]error
+		withDLQ       bool
+		wantBatches   [][]string
+		wantCommitted []int64
+		wantDLQ       int
+		wantCalls     map[string]int
+	}{
+		{
+			name:          "пакет собирается по размеру и по времени ожидания",
+			policy:        BatchPolicy{Size: 2, Linger: 10 * time.Millisecond},
+			wantBatches:   [][]string{{"a", "b"}, {"c"}},
+			wantCommitted: []int64{0, 1, 2},
+		},
+		{
+			name:          "ошибка отдельного сообщения уходит в dlq",
+			policy:        BatchPolicy{Size: 3, Linger: 10 * time.Millisecond},
+			batchResults:  map[string]error{"b": permanentErr},
+			withDLQ:       true,
+			wantBatches:   [][]string{{"a", "b", "c"}},
+			wantCommitted: []int64{0, 1, 2},
+			wantDLQ:       1,
+		},
+		{
+			name:          "временная ошибка пакета повторяется целиком",
+			policy:        BatchPolicy{Size: 3, Linger: 10 * time.Millisecond},
+			batchErrs:     []error{retryableErr},
+			wantBatches:   [][]string{{"a", "b", "c"}, {"a", "b", "c"}},
+			wantCommitted: []int64{0, 1, 2},
+		},
+		{
+			name:          "непрошедший пакет обрабатывается по одному сообщению",
+			policy:        BatchPolicy{Size: 3, Linger: 10 * time.Millisecond},
+			batchErrs:     []error{errors.New("boom")},
+			wantBatches:   [][]string{{"a", "b", "c"}},
+			wantCommitted: []int64{0, 1, 2},
+			wantCalls:     map[string]int{"a": 1, "b": 1, "c": 1},
+		},
+		{
+			name:          "временная ошибка сообщения повторяется отдельно",
+			policy:        BatchPolicy{Size: 3, Linger: 10 * time.Millisecond},
+			batchResults:  map[string]error{"b": retryableErr},
+			wantBatches:   [][]string{{"a", "b", "c"}},
+			wantCommitted: []int64{0, 1, 2},
+			wantCalls:     map[string]int{"a": 0, "b": 1, "c": 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			reader := &stubReader{
+				messages: []kafka.Message{
+					{Topic: "order-service", Offset: 0, Value: []byte("a")},
+					{Topic: "order-service", Offset: 1, Value: []byte("b")},
+					{Topic: "order-service", Offset: 2, Value: []byte("c")},
+				},
+				cancel: cancel,
+				block:  true,
+			}
+			handler := &stubBatchHandler{
+				stubHandler:  stubHandler{errs: tt.handlerErrs, called: map[string]int{}},
+				batchErrs:    tt.batchErrs,
+				batchResults: tt.batchResults,
+			}
+
+			var writer *kafkatest.Writer
+			var dlq *DeadLetterQueue
+			if tt.withDLQ {
+				writer = &kafkatest.Writer{}
+				dlq = NewDeadLetterQueue(writer)
+			}
+
+			c := NewConsumer(reader, zap.NewNop(), handler, dlq, RetryPolicy{MaxAttempts: 3})
+
+			var wg sync.WaitGroup
+			wg.Add(1)
+			c.ConsumeBatches(ctx, &wg, tt.policy)
+			wg.Wait()
+
+			assert.Equal(t, tt.wantBatches, handler.batches)
+			assert.Equal(t, tt.wantCommitted, reader.committed)
+			if writer != nil {
+				assert.Len(t, writer.Messages(), tt.wantDLQ)
+			}
+			for key, calls := range tt.wantCalls {
+				assert.Equal(t, calls, handler.called[key], key)
+			}
+		})
+	}
+}
+
+func TestConsumer_ConsumeBatches_DropsPartialBatchOnShutdown(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reader := &stubReader{
+		messages: []kafka.Message{
+			{Topic: "order-service", Offset: 0, Value: []byte("a")},
+			{Topic: "order-service", Offset: 1, Value: []byte("b")},
+		},
+		cancel: cancel,
+	}
+	handler := &stubBatchHandler{stubHandler: stubHandler{called: map[string]int{}}}
+
+	c := NewConsumer(reader, zap.NewNop(), handler, nil, RetryPolicy{MaxAttempts: 3})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	c.ConsumeBatches(ctx, &wg, BatchPolicy{Size: 10, Linger: time.Second})
+	wg.Wait()
+
+	assert.Empty(t, handler.batches)
+	assert.Empty(t, reader.committed)
+}
+
+func TestConsumer_ConsumeBatches_DefaultLinger(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	reader := &stubReader{
+		messages: []kafka.Message{
+			{Topic: "order-service", Offset: 0, Value: []byte("a")},
+			{Topic: "order-service", Offset: 1, Value: []byte("b")},
+		},
+		cancel: cancel,
+		block:  true,
+	}
+	handler := &stubBatchHandler{stubHandler: stubHandler{called: map[string]int{}}}
+
+	c := NewConsumer(reader, zap.NewNop(), handler, nil, RetryPolicy{MaxAttempts: 3})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	c.ConsumeBatches(ctx, &wg, BatchPolicy{Size: 10})
+	wg.Wait()
+
+	assert.NotErrorIs(t, ctx.Err(), context.DeadlineExceeded, "неполный пакет не должен ждать без конца")
+	assert.Equal(t, []int64{0, 1}, reader.committed)
+}
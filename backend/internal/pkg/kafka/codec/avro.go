@@ -0,0 +1,253 @@
+package codec
+
+import (
+	_ "embed"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hamba/avro/v2"
+
+	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+	"github.com/avraam311/order-service/backend/internal/pkg/kafka/envelope"
+)
+
+// OrderSchema — avro схема конверта order.created текущей версии.
+//
+//go:embed order.avsc
+var OrderSchema string
+
+// avroMagic начинает сообщение в формате confluent: 0, затем id схемы в
+// big-endian uint32, затем данные avro.
+const (
+	avroMagic      = 0
+	avroHeaderSize = 5
+)
+
+type Avro struct {
+	registry *FileRegistry
+	schemaID int
+}
+
+func NewAvro(r *FileRegistry, schemaID int) *Avro {
+	return &Avro{
+		registry: r,
+		schemaID: schemaID,
+	}
+}
+
+func (a *Avro) ContentType() string {
+	return ContentTypeAvro
+}
+
+func (a *Avro) Encode(producerID string, order *models.Order) ([]byte, error) {
+	schema, err := a.registry.Schema(a.schemaID)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := avro.Marshal(schema, avroEnvelope{
+		EventType:     envelope.EventOrderCreated,
+		SchemaVersion: envelope.CurrentOrderVersion,
+		ProducerID:    producerID,
+		OccurredAt:    time.Now().UTC(),
+		Payload:       orderToAvro(order),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("backend/internal/pkg/kafka/codec/avro.go, кодирование заказа: %w", err)
+	}
+
+	msg := make([]byte, avroHeaderSize, avroHeaderSize+len(body))
+	msg[0] = avroMagic
+	binary.BigEndian.PutUint32(msg[1:avroHeaderSize], uint32(a.schemaID))
+
+	return append(msg, body...), nil
+}
+
+func (a *Avro) Decode(msg []byte) (*models.Order, error) {
+	if len(msg) < avroHeaderSize || msg[0] != avroMagic {
+		return nil, fmt.Errorf("нет заголовка avro: %w", apperrors.ErrInvalidMessage)
+	}
+
+	schema, err := a.registry.Schema(int(binary.BigEndian.Uint32(msg[1:avroHeaderSize])))
+	if err != nil {
+		return nil, err
+	}
+
+	var env avroEnvelope
+	if err = avro.Unmarshal(schema, msg[avroHeaderSize:], &env); err != nil {
+		return nil, fmt.Errorf("%w: %w", apperrors.ErrInvalidMessage, err)
+	}
+
+	if err = checkEnvelope(env.EventType, env.SchemaVersion); err != nil {
+		return nil, err
+	}
+
+	return orderFromAvro(env.Payload)
+}
+
+type avroEnvelope struct {
+	EventType     string    `avro:"event_type"`
+	SchemaVersion int       `avro:"schema_version"`
+	ProducerID    string    `avro:"producer_id"`
+	OccurredAt    time.Time `avro:"occurred_at"`
+	Payload       avroOrder `avro:"payload"`
+}
+
+type avroOrder struct {
+	OrderID           string       `avro:"order_uid"`
+	TrackNumber       string       `avro:"track_number"`
+	Entry             string       `avro:"entry"`
+	Delivery          avroDelivery `avro:"delivery"`
+	Payment           avroPayment  `avro:"payment"`
+	Items             []avroItem   `avro:"items"`
+	Locale            string       `avro:"locale"`
+	InternalSignature string       `avro:"internal_signature"`
+	CustomerID        string       `avro:"customer_id"`
+	DeliveryService   string       `avro:"delivery_service"`
+	Shardkey          string       `avro:"shardkey"`
+	SmID              int64        `avro:"sm_id"`
+	DateCreated       time.Time    `avro:"date_created"`
+	OofShard          string       `avro:"oof_shard"`
+	Status            string       `avro:"status"`
+}
+
+type avroDelivery struct {
+	Name    string `avro:"name"`
+	Phone   string `avro:"phone"`
+	Zip     string `avro:"zip"`
+	City    string `avro:"city"`
+	Address string `avro:"address"`
+	Region  string `avro:"region"`
+	Email   string `avro:"email"`
+}
+
+type avroPayment struct {
+	Transaction  string `avro:"transaction"`
+	RequestID    string `avro:"request_id"`
+	Currency     string `avro:"currency"`
+	Provider     string `avro:"provider"`
+	Amount       int64  `avro:"amount"`
+	PaymentDT    int64  `avro:"payment_dt"`
+	Bank         string `avro:"bank"`
+	DeliveryCost int64  `avro:"delivery_cost"`
+	GoodsTotal   int64  `avro:"goods_total"`
+	CustomFee    int64  `avro:"custom_fee"`
+}
+
+type avroItem struct {
+	ChrtID      int64  `avro:"chrt_id"`
+	TrackNumber string `avro:"track_number"`
+	Price       int64  `avro:"price"`
+	RID         string `avro:"rid"`
+	Name        string `avro:"name"`
+	Sale        int64  `avro:"sale"`
+	Size        string `avro:"size"`
+	TotalPrice  int64  `avro:"total_price"`
+	NmID        int64  `avro:"nm_id"`
+	Brand       string `avro:"brand"`
+	Status      int64  `avro:"status"`
+}
+
+func orderToAvro(o *models.Order) avroOrder {
+	items := make([]avroItem, len(o.Items))
+	for i, item := range o.Items {
+		items[i] = avroItem{
+			ChrtID:      int64(item.ChrtID),
+			TrackNumber: item.TrackNumber,
+			Price:       int64(item.Price),
+			RID:         item.RID,
+			Name:        item.Name,
+			Sale:        int64(item.Sale),
+			Size:        item.Size,
+			TotalPrice:  int64(item.TotalPrice),
+			NmID:        int64(item.NmID),
+			Brand:       item.Brand,
+			Status:      int64(item.Status),
+		}
+	}
+
+	return avroOrder{
+		OrderID:     o.OrderID.String(),
+		TrackNumber: o.TrackNumber,
+		Entry:       o.Entry,
+		Delivery:    avroDelivery(o.Delivery),
+		Payment: avroPayment{
+			Transaction:  o.Payment.Transaction,
+			RequestID:    o.Payment.RequestID,
+			Currency:     o.Payment.Currency,
+			Provider:     o.Payment.Provider,
+			Amount:       int64(o.Payment.Amount),
+			PaymentDT:    o.Payment.PaymentDT,
+			Bank:         o.Payment.Bank,
+			DeliveryCost: int64(o.Payment.DeliveryCost),
+			GoodsTotal:   int64(o.Payment.GoodsTotal),
+			CustomFee:    int64(o.Payment.CustomFee),
+		},
+		Items:             items,
+		Locale:            o.Locale,
+		InternalSignature: o.InternalSignature,
+		CustomerID:        o.CustomerId,
+		DeliveryService:   o.DeliveryService,
+		Shardkey:          o.Shardkey,
+		SmID:              int64(o.SmId),
+		DateCreated:       o.DateCreated,
+		OofShard:          o.OofShard,
+		Status:            string(o.Status),
+	}
+}
+
+func orderFromAvro(a avroOrder) (*models.Order, error) {
+	orderID, err := uuid.Parse(a.OrderID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: order_uid: %w", apperrors.ErrInvalidMessage, err)
+	}
+
+	items := make([]models.Item, len(a.Items))
+	for i, item := range a.Items {
+		items[i] = models.Item{
+			ChrtID:      int(item.ChrtID),
+			TrackNumber: item.TrackNumber,
+			Price:       int(item.Price),
+			RID:         item.RID,
+			Name:        item.Name,
+			Sale:        int(item.Sale),
+			Size:        item.Size,
+			TotalPrice:  int(item.TotalPrice),
+			NmID:        int(item.NmID),
+			Brand:       item.Brand,
+			Status:      int(item.Status),
+		}
+	}
+
+	return &models.Order{
+		OrderID:     orderID,
+		TrackNumber: a.TrackNumber,
+		Entry:       a.Entry,
+		Delivery:    models.Delivery(a.Delivery),
+		Payment: models.Payment{
+			Transaction:  a.Payment.Transaction,
+			RequestID:    a.Payment.RequestID,
+			Currency:     a.Payment.Currency,
+			Provider:     a.Payment.Provider,
+			Amount:       int(a.Payment.Amount),
+			PaymentDT:    a.Payment.PaymentDT,
+			Bank:         a.Payment.Bank,
+			DeliveryCost: int(a.Payment.DeliveryCost),
+			GoodsTotal:   int(a.Payment.GoodsTotal),
+			CustomFee:    int(a.Payment.CustomFee),
+		},
+		Items:             items,
+		Locale:            a.Locale,
+		InternalSignature: a.InternalSignature,
+		CustomerId:        a.CustomerID,
+		DeliveryService:   a.DeliveryService,
+		Shardkey:          a.Shardkey,
+		SmId:              int(a.SmID),
+		DateCreated:       a.DateCreated,
+		OofShard:          a.OofShard,
+		Status:            models.OrderStatus(a.Status),
+	}, nil
+}
@@ -0,0 +1,93 @@
+package codec
+
+import (
+	"fmt"
+	"mime"
+	"strings"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+	"github.com/avraam311/order-service/backend/internal/pkg/kafka/envelope"
+)
+
+const (
+	ContentTypeHeader = "content-type"
+
+	ContentTypeJSON     = "application/json"
+	ContentTypeProtobuf = "application/x-protobuf"
+	ContentTypeAvro     = "application/avro"
+)
+
+// Codec кодирует и разбирает событие order.created в одном формате.
+type Codec interface {
+	ContentType() string
+	Encode(producerID string, order *models.Order) ([]byte, error)
+	Decode(msg []byte) (*models.Order, error)
+}
+
+type Set struct {
+	codecs map[string]Codec
+}
+
+func NewSet(codecs ...Codec) *Set {
+	s := &Set{codecs: make(map[string]Codec, len(codecs))}
+	for _, c := range codecs {
+		s.codecs[c.ContentType()] = c
+	}
+
+	return s
+}
+
+// Get возвращает кодек по content-type. Пустой content-type означает json,
+// как было до появления других форматов.
+func (s *Set) Get(contentType string) (Codec, error) {
+	mediaType := ContentTypeJSON
+	if contentType != "" {
+		var err error
+		if mediaType, _, err = mime.ParseMediaType(contentType); err != nil {
+			return nil, fmt.Errorf("content-type %q: %w", contentType, apperrors.ErrUnsupportedContentType)
+		}
+	}
+
+	c, ok := s.codecs[strings.ToLower(mediaType)]
+	if !ok {
+		return nil, fmt.Errorf("content-type %q: %w", contentType, apperrors.ErrUnsupportedContentType)
+	}
+
+	return c, nil
+}
+
+func (s *Set) Decode(contentType string, msg []byte) (*models.Order, error) {
+	c, err := s.Get(contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Decode(msg)
+}
+
+func ContentTypeOf(m kafka.Message) string {
+	for _, h := range m.Headers {
+		if strings.EqualFold(h.Key, ContentTypeHeader) {
+			return string(h.Value)
+		}
+	}
+
+	return ""
+}
+
+// checkEnvelope проверяет тип события и версию для бинарных форматов: их схемы
+// развиваются совместимо, поэтому поддерживается только текущая версия.
+func checkEnvelope(eventType string, version int) error {
+	if eventType != envelope.EventOrderCreated {
+		return fmt.Errorf("event_type %q: %w", eventType, apperrors.ErrUnknownEventType)
+	}
+
+	if version != envelope.CurrentOrderVersion {
+		return fmt.Errorf("schema_version %d: %w", version, apperrors.ErrUnknownSchemaVersion)
+	}
+
+	return nil
+}
@@ -0,0 +1,198 @@
+package codec
+
+import (
+	"encoding/binary"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/models/modeltest"
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+	"github.com/avraam311/order-service/backend/internal/pkg/kafka/codec/orderpb"
+	"github.com/avraam311/order-service/backend/internal/pkg/kafka/envelope"
+)
+
+// testOrder дополняет эталонный заказ полями, которые в нем пустые, чтобы
+// кодеки проверялись на всех полях.
+func testOrder(t *testing.T) *models.Order {
+	o := modeltest.Order(t)
+	o.Payment.RequestID = "req-1"
+	o.Payment.CustomFee = 5
+	o.InternalSignature = "sig"
+	o.DateCreated = time.Date(2021, 11, 26, 6, 22, 19, 123456000, time.UTC)
+	o.Status = models.StatusCreated
+
+	second := o.Items[0]
+	second.ChrtID++
+	second.RID += "2"
+	second.Name = "Lipstick"
+	second.Sale = 0
+	second.TotalPrice = second.Price
+	o.Items = append(o.Items, second)
+
+	return o
+}
+
+func testSet(t *testing.T) (*Set, *FileRegistry) {
+	t.Helper()
+
+	registry := NewFileRegistry(t.TempDir())
+	id, err := registry.Register(OrderSchema)
+	require.NoError(t, err)
+
+	return NewSet(JSON{}, Protobuf{}, NewAvro(registry, id)), registry
+}
+
+func TestSet_RoundTrip(t *testing.T) {
+	set, _ := testSet(t)
+	want := testOrder(t)
+
+	decoded := make(map[string]*models.Order)
+	for _, contentType := range []string{ContentTypeJSON, ContentTypeProtobuf, ContentTypeAvro} {
+		t.Run(contentType, func(t *testing.T) {
+			c, err := set.Get(contentType)
+			require.NoError(t, err)
+
+			msg, err := c.Encode("test-producer", want)
+			require.NoError(t, err)
+
+			got, err := set.Decode(contentType, msg)
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+
+			decoded[contentType] = got
+		})
+	}
+
+	assert.Equal(t, decoded[ContentTypeJSON], decoded[ContentTypeProtobuf])
+	assert.Equal(t, decoded[ContentTypeJSON], decoded[ContentTypeAvro])
+}
+
+func TestSet_Decode_Errors(t *testing.T) {
+	set, _ := testSet(t)
+
+	jsonMsg, err := JSON{}.Encode("test-producer", testOrder(t))
+	require.NoError(t, err)
+
+	newerProto, err := proto.Marshal(&orderpb.OrderEnvelope{
+		EventType:     "order.created",
+		SchemaVersion: envelope.CurrentOrderVersion + 1,
+		Payload:       orderToProto(testOrder(t)),
+	})
+	require.NoError(t, err)
+
+	c, err := set.Get(ContentTypeAvro)
+	require.NoError(t, err)
+	avroMsg, err := c.Encode("test-producer", testOrder(t))
+	require.NoError(t, err)
+	unknownSchema := append([]byte(nil), avroMsg...)
+	binary.BigEndian.PutUint32(unknownSchema[1:avroHeaderSize], 42)
+
+	tests := []struct {
+		name        string
+		contentType string
+		msg         []byte
+		wantErr     error
+	}{
+		{
+			name:        "json без content-type",
+			contentType: "",
+			msg:         jsonMsg,
+		},
+		{
+			name:        "content-type с параметрами",
+			contentType: "Application/JSON; charset=utf-8",
+			msg:         jsonMsg,
+		},
+		{
+			name:        "неподдерживаемый content-type",
+			contentType: "text/xml",
+			msg:         jsonMsg,
+			wantErr:     apperrors.ErrUnsupportedContentType,
+		},
+		{
+			name:        "json в protobuf",
+			contentType: ContentTypeProtobuf,
+			msg:         jsonMsg,
+			wantErr:     apperrors.ErrInvalidMessage,
+		},
+		{
+			name:        "неизвестная версия protobuf",
+			contentType: ContentTypeProtobuf,
+			msg:         newerProto,
+			wantErr:     apperrors.ErrUnknownSchemaVersion,
+		},
+		{
+			name:        "avro без заголовка",
+			contentType: ContentTypeAvro,
+			msg:         avroMsg[avroHeaderSize:],
+			wantErr:     apperrors.ErrInvalidMessage,
+		},
+		{
+			name:        "avro с неизвестной схемой",
+			contentType: ContentTypeAvro,
+			msg:         unknownSchema,
+			wantErr:     apperrors.ErrUnknownSchemaVersion,
+		},
+		{
+			name:        "обрезанный avro",
+			contentType: ContentTypeAvro,
+			msg:         avroMsg[:len(avroMsg)/2],
+			wantErr:     apperrors.ErrInvalidMessage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, err := set.Decode(tt.contentType, tt.msg)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testOrder(t), order)
+		})
+	}
+}
+
+func TestFileRegistry_Register(t *testing.T) {
+	dir := t.TempDir()
+	registry := NewFileRegistry(dir)
+
+	id, err := registry.Register(OrderSchema)
+	require.NoError(t, err)
+	assert.Equal(t, 1, id)
+
+	again, err := NewFileRegistry(dir).Register(OrderSchema)
+	require.NoError(t, err)
+	assert.Equal(t, id, again, "та же схема не регистрируется повторно")
+
+	evolved := strings.Replace(OrderSchema,
+		`{"name": "status", "type": "string", "default": ""}`,
+		`{"name": "status", "type": "string", "default": ""},
+        {"name": "comment", "type": "string", "default": ""}`, 1)
+	require.NotEqual(t, OrderSchema, evolved)
+
+	next, err := registry.Register(evolved)
+	require.NoError(t, err)
+	assert.Equal(t, 2, next)
+
+	_, err = registry.Schema(3)
+	assert.ErrorIs(t, err, apperrors.ErrUnknownSchemaVersion)
+
+	_, err = registry.Register(`{"type": "record"`)
+	assert.Error(t, err)
+}
+
+func TestContentTypeOf(t *testing.T) {
+	m := kafka.Message{Headers: []kafka.Header{{Key: "Content-Type", Value: []byte(ContentTypeAvro)}}}
+	assert.Equal(t, ContentTypeAvro, ContentTypeOf(m))
+	assert.Empty(t, ContentTypeOf(kafka.Message{}))
+}
@@ -0,0 +1,38 @@
+package codec
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/pkg/kafka/envelope"
+)
+
+type JSON struct{}
+
+func (JSON) ContentType() string {
+	return ContentTypeJSON
+}
+
+func (JSON) Encode(producerID string, order *models.Order) ([]byte, error) {
+	env, err := envelope.New(envelope.EventOrderCreated, envelope.CurrentOrderVersion, producerID, order)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := json.Marshal(env)
+	if err != nil {
+		return nil, fmt.Errorf("backend/internal/pkg/kafka/codec/json.go, кодирование конверта: %w", err)
+	}
+
+	return msg, nil
+}
+
+func (JSON) Decode(msg []byte) (*models.Order, error) {
+	env, err := envelope.Decode(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return envelope.DecodeOrder(env)
+}
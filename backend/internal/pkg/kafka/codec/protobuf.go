@@ -0,0 +1,181 @@
+package codec
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+	"github.com/avraam311/order-service/backend/internal/pkg/kafka/codec/orderpb"
+	"github.com/avraam311/order-service/backend/internal/pkg/kafka/envelope"
+)
+
+type Protobuf struct{}
+
+func (Protobuf) ContentType() string {
+	return ContentTypeProtobuf
+}
+
+func (Protobuf) Encode(producerID string, order *models.Order) ([]byte, error) {
+	msg, err := proto.Marshal(&orderpb.OrderEnvelope{
+		EventType:     envelope.EventOrderCreated,
+		SchemaVersion: envelope.CurrentOrderVersion,
+		ProducerId:    producerID,
+		OccurredAt:    timestamppb.New(time.Now()),
+		Payload:       orderToProto(order),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("backend/internal/pkg/kafka/codec/protobuf.go, кодирование заказа: %w", err)
+	}
+
+	return msg, nil
+}
+
+func (Protobuf) Decode(msg []byte) (*models.Order, error) {
+	var env orderpb.OrderEnvelope
+	if err := proto.Unmarshal(msg, &env); err != nil {
+		return nil, fmt.Errorf("%w: %w", apperrors.ErrInvalidMessage, err)
+	}
+
+	if err := checkEnvelope(env.GetEventType(), int(env.GetSchemaVersion())); err != nil {
+		return nil, err
+	}
+
+	if env.GetPayload() == nil {
+		return nil, apperrors.ErrEmptyOrder
+	}
+
+	return orderFromProto(env.GetPayload())
+}
+
+func orderToProto(o *models.Order) *orderpb.Order {
+	items := make([]*orderpb.Item, len(o.Items))
+	for i, item := range o.Items {
+		items[i] = &orderpb.Item{
+			ChrtId:      int64(item.ChrtID),
+			TrackNumber: item.TrackNumber,
+			Price:       int64(item.Price),
+			Rid:         item.RID,
+			Name:        item.Name,
+			Sale:        int64(item.Sale),
+			Size:        item.Size,
+			TotalPrice:  int64(item.TotalPrice),
+			NmId:        int64(item.NmID),
+			Brand:       item.Brand,
+			Status:      int64(item.Status),
+		}
+	}
+
+	var dateCreated *timestamppb.Timestamp
+	if !o.DateCreated.IsZero() {
+		dateCreated = timestamppb.New(o.DateCreated)
+	}
+
+	return &orderpb.Order{
+		OrderUid:    o.OrderID.String(),
+		TrackNumber: o.TrackNumber,
+		Entry:       o.Entry,
+		Delivery: &orderpb.Delivery{
+			Name:    o.Delivery.Name,
+			Phone:   o.Delivery.Phone,
+			Zip:     o.Delivery.Zip,
+			City:    o.Delivery.City,
+			Address: o.Delivery.Address,
+			Region:  o.Delivery.Region,
+			Email:   o.Delivery.Email,
+		},
+		Payment: &orderpb.Payment{
+			Transaction:  o.Payment.Transaction,
+			RequestId:    o.Payment.RequestID,
+			Currency:     o.Payment.Currency,
+			Provider:     o.Payment.Provider,
+			Amount:       int64(o.Payment.Amount),
+			PaymentDt:    o.Payment.PaymentDT,
+			Bank:         o.Payment.Bank,
+			DeliveryCost: int64(o.Payment.DeliveryCost),
+			GoodsTotal:   int64(o.Payment.GoodsTotal),
+			CustomFee:    int64(o.Payment.CustomFee),
+		},
+		Items:             items,
+		Locale:            o.Locale,
+		InternalSignature: o.InternalSignature,
+		CustomerId:        o.CustomerId,
+		DeliveryService:   o.DeliveryService,
+		Shardkey:          o.Shardkey,
+		SmId:              int64(o.SmId),
+		DateCreated:       dateCreated,
+		OofShard:          o.OofShard,
+		Status:            string(o.Status),
+	}
+}
+
+func orderFromProto(p *orderpb.Order) (*models.Order, error) {
+	orderID, err := uuid.Parse(p.GetOrderUid())
+	if err != nil {
+		return nil, fmt.Errorf("%w: order_uid: %w", apperrors.ErrInvalidMessage, err)
+	}
+
+	items := make([]models.Item, len(p.GetItems()))
+	for i, item := range p.GetItems() {
+		items[i] = models.Item{
+			ChrtID:      int(item.GetChrtId()),
+			TrackNumber: item.GetTrackNumber(),
+			Price:       int(item.GetPrice()),
+			RID:         item.GetRid(),
+			Name:        item.GetName(),
+			Sale:        int(item.GetSale()),
+			Size:        item.GetSize(),
+			TotalPrice:  int(item.GetTotalPrice()),
+			NmID:        int(item.GetNmId()),
+			Brand:       item.GetBrand(),
+			Status:      int(item.GetStatus()),
+		}
+	}
+
+	var dateCreated time.Time
+	if p.GetDateCreated() != nil {
+		dateCreated = p.GetDateCreated().AsTime()
+	}
+
+	d, pay := p.GetDelivery(), p.GetPayment()
+	return &models.Order{
+		OrderID:     orderID,
+		TrackNumber: p.GetTrackNumber(),
+		Entry:       p.GetEntry(),
+		Delivery: models.Delivery{
+			Name:    d.GetName(),
+			Phone:   d.GetPhone(),
+			Zip:     d.GetZip(),
+			City:    d.GetCity(),
+			Address: d.GetAddress(),
+			Region:  d.GetRegion(),
+			Email:   d.GetEmail(),
+		},
+		Payment: models.Payment{
+			Transaction:  pay.GetTransaction(),
+			RequestID:    pay.GetRequestId(),
+			Currency:     pay.GetCurrency(),
+			Provider:     pay.GetProvider(),
+			Amount:       int(pay.GetAmount()),
+			PaymentDT:    pay.GetPaymentDt(),
+			Bank:         pay.GetBank(),
+			DeliveryCost: int(pay.GetDeliveryCost()),
+			GoodsTotal:   int(pay.GetGoodsTotal()),
+			CustomFee:    int(pay.GetCustomFee()),
+		},
+		Items:             items,
+		Locale:            p.GetLocale(),
+		InternalSignature: p.GetInternalSignature(),
+		CustomerId:        p.GetCustomerId(),
+		DeliveryService:   p.GetDeliveryService(),
+		Shardkey:          p.GetShardkey(),
+		SmId:              int(p.GetSmId()),
+		DateCreated:       dateCreated,
+		OofShard:          p.GetOofShard(),
+		Status:            models.OrderStatus(p.GetStatus()),
+	}, nil
+}
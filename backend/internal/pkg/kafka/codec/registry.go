@@ -0,0 +1,126 @@
+package codec
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hamba/avro/v2"
+
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+)
+
+// FileRegistry — локальная замена schema registry: схема с id N хранится в
+// файле N.avsc в каталоге dir.
+type FileRegistry struct {
+	dir string
+
+	mu      sync.Mutex
+	schemas map[int]avro.Schema
+}
+
+func NewFileRegistry(dir string) *FileRegistry {
+	return &FileRegistry{
+		dir:     dir,
+		schemas: make(map[int]avro.Schema),
+	}
+}
+
+// Register возвращает id схемы, сохраняя ее под новым id, если такой схемы
+// еще нет.
+func (r *FileRegistry) Register(schema string) (int, error) {
+	parsed, err := avro.ParseWithCache(schema, "", &avro.SchemaCache{})
+	if err != nil {
+		return 0, fmt.Errorf("backend/internal/pkg/kafka/codec/registry.go, разбор схемы: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ids, err := r.ids()
+	if err != nil {
+		return 0, err
+	}
+
+	next := 1
+	for _, id := range ids {
+		existing, err := r.load(id)
+		if err != nil {
+			return 0, err
+		}
+		if existing.Fingerprint() == parsed.Fingerprint() {
+			return id, nil
+		}
+		next = max(next, id+1)
+	}
+
+	if err = os.MkdirAll(r.dir, 0o755); err != nil {
+		return 0, fmt.Errorf("backend/internal/pkg/kafka/codec/registry.go, создание каталога схем: %w", err)
+	}
+
+	if err = os.WriteFile(r.path(next), []byte(schema), 0o644); err != nil {
+		return 0, fmt.Errorf("backend/internal/pkg/kafka/codec/registry.go, запись схемы: %w", err)
+	}
+	r.schemas[next] = parsed
+
+	return next, nil
+}
+
+func (r *FileRegistry) Schema(id int) (avro.Schema, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.load(id)
+}
+
+func (r *FileRegistry) load(id int) (avro.Schema, error) {
+	if s, ok := r.schemas[id]; ok {
+		return s, nil
+	}
+
+	raw, err := os.ReadFile(r.path(id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("schema id %d: %w", id, apperrors.ErrUnknownSchemaVersion)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("backend/internal/pkg/kafka/codec/registry.go, чтение схемы %d: %w", id, err)
+	}
+
+	s, err := avro.ParseBytesWithCache(raw, "", &avro.SchemaCache{})
+	if err != nil {
+		return nil, fmt.Errorf("backend/internal/pkg/kafka/codec/registry.go, разбор схемы %d: %w", id, err)
+	}
+	r.schemas[id] = s
+
+	return s, nil
+}
+
+func (r *FileRegistry) ids() ([]int, error) {
+	entries, err := os.ReadDir(r.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("backend/internal/pkg/kafka/codec/registry.go, чтение каталога схем: %w", err)
+	}
+
+	var ids []int
+	for _, e := range entries {
+		id, err := strconv.Atoi(strings.TrimSuffix(e.Name(), ".avsc"))
+		if err != nil || e.IsDir() || !strings.HasSuffix(e.Name(), ".avsc") {
+			continue
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+func (r *FileRegistry) path(id int) string {
+	return filepath.Join(r.dir, strconv.Itoa(id)+".avsc")
+}
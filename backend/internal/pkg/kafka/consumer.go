@@ -3,40 +3,52 @@ package kafka
 import (
 	"context"
 	"errors"
-	"strings"
+	"io"
 	"sync"
 	"time"
 
 	"github.com/segmentio/kafka-go"
 	"go.uber.org/zap"
+
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
 )
 
 type messageHandler interface {
-	HandleMessage(ctx context.Context, msg []byte) error
+	HandleMessage(ctx context.Context, m kafka.Message) error
+}
+
+type messageReader interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, msgs ...kafka.Message) error
+	Config() kafka.ReaderConfig
+	Close() error
 }
 
 type Consumer struct {
-	reader  *kafka.Reader
-	logger  *zap.Logger
-	handler messageHandler
+	reader messageReader
+	logger *zap.Logger
+	router *Router
 }
 
-func NewReader(groupID string, topic string, brokers []string) *kafka.Reader {
+func NewReader(groupID string, topics []string, brokers []string) *kafka.Reader {
 	return kafka.NewReader(kafka.ReaderConfig{
-		Brokers:        brokers,
-		GroupID:        groupID,
-		Topic:          topic,
-		StartOffset:    kafka.FirstOffset,
-		CommitInterval: time.Second,
-		MaxBytes:       10e6,
+		Brokers:     brokers,
+		GroupID:     groupID,
+		GroupTopics: topics,
+		StartOffset: kafka.FirstOffset,
+		MaxBytes:    10e6,
 	})
 }
 
-func NewConsumer(r *kafka.Reader, l *zap.Logger, h messageHandler) *Consumer {
+func NewConsumer(r messageReader, l *zap.Logger, h messageHandler, dlq *DeadLetterQueue, retry RetryPolicy) *Consumer {
+	return NewRoutedConsumer(r, l, NewRouter(dlq, Route{Handler: h, Retry: retry, DLQ: dlq}))
+}
+
+func NewRoutedConsumer(r messageReader, l *zap.Logger, router *Router) *Consumer {
 	return &Consumer{
-		reader:  r,
-		logger:  l,
-		handler: h,
+		reader: r,
+		logger: l,
+		router: router,
 	}
 }
 
@@ -44,79 +56,188 @@ func (c *Consumer) ConsumeMessage(ctx context.Context, wg *sync.WaitGroup) {
 	defer wg.Done()
 	defer c.Close()
 
+	c.start(ctx)
+
+	for {
+		m, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if c.fetchStopped(err) {
+				break
+			}
+			continue
+		}
+
+		if !c.settle(ctx, m, c.handleWithRetry(ctx, m)) {
+			break
+		}
+
+		c.commit(ctx, m)
+	}
+
+	c.logger.Info("чтение сообщения завершено")
+}
+
+func (c *Consumer) start(ctx context.Context) {
 	go func() {
 		<-ctx.Done()
 		c.logger.Info("получен сигнал shutdown, закрытие консьюмера")
 		c.Close()
 	}()
 
+	cfg := c.reader.Config()
 	c.logger.Info("читаем сообщения",
-		zap.String("topic", c.reader.Config().Topic),
-		zap.String("groupID", c.reader.Config().GroupID),
+		zap.String("topic", cfg.Topic),
+		zap.Strings("topics", cfg.GroupTopics),
+		zap.String("groupID", cfg.GroupID),
 	)
+}
 
-	for {
-		m, err := c.reader.ReadMessage(ctx)
-		if err != nil {
-			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
-				c.logger.Warn("backend/internal/pkg/kafka/consumer.go, контекст отменен или прошел дедлайн, закрытие консьюмера", zap.Error(err))
-				break
-			}
+func (c *Consumer) fetchStopped(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		c.logger.Warn("backend/internal/pkg/kafka/consumer.go, контекст отменен или прошел дедлайн, закрытие консьюмера", zap.Error(err))
+		return true
+	}
 
-			c.logger.Error("backend/internal/pkg/kafka/consumer.go, ошибка чтения сообщения", zap.Error(err))
-			continue
-		}
+	if errors.Is(err, io.EOF) {
+		c.logger.Warn("backend/internal/pkg/kafka/consumer.go, ридер закрыт", zap.Error(err))
+		return true
+	}
 
-		if err = c.handler.HandleMessage(ctx, m.Value); err != nil {
-			c.handleMessageError(m, err)
-			continue
-		}
+	c.logger.Error("backend/internal/pkg/kafka/consumer.go, ошибка чтения сообщения", zap.Error(err))
+	return false
+}
+
+// settle завершает обработку сообщения: логирует результат и при необходимости
+// отправляет его в dlq. false означает, что оффсет коммитить нельзя и консьюмер
+// должен остановиться.
+func (c *Consumer) settle(ctx context.Context, m kafka.Message, err error) bool {
+	if errors.Is(err, apperrors.ErrOrderDuplicate) {
+		c.logger.Info("повторная доставка заказа, сообщение пропущено",
+			zap.Int64("offset", m.Offset),
+			zap.Error(err),
+		)
+		return true
+	}
 
+	if err == nil {
 		c.logger.Info("сообщения успешно прочтено",
 			zap.Int64("offset", m.Offset),
 			zap.String("message", string(m.Value)),
 		)
+		return true
 	}
 
-	c.logger.Info("чтение сообщения завершено")
-}
-
-func (c *Consumer) handleMessageError(m kafka.Message, err error) {
-	msgStr := string(m.Value)
-
-	errMsg := err.Error()
-	switch {
-	case strings.Contains(errMsg, "неправильный json"):
-		c.logger.Warn("неправильный json",
+	if ctx.Err() != nil {
+		c.logger.Warn("backend/internal/pkg/kafka/consumer.go, обработка сообщения прервана shutdown",
 			zap.Int64("offset", m.Offset),
-			zap.String("message", msgStr),
 			zap.Error(err),
 		)
-	case strings.Contains(errMsg, "пустой заказ"):
-		c.logger.Warn("получен пустой заказ",
+		return false
+	}
+
+	dlq := c.router.match(m).DLQ
+	if apperrors.IsRetryable(err) && dlq == nil {
+		c.logger.Error("backend/internal/pkg/kafka/consumer.go, попытки обработки сообщения исчерпаны, остановка консьюмера",
 			zap.Int64("offset", m.Offset),
-			zap.String("message", msgStr),
 			zap.Error(err),
 		)
-	case strings.Contains(errMsg, "ошибка создания заказа"):
-		c.logger.Warn("ошибка при создании заказа",
-			zap.Int64("offset", m.Offset),
-			zap.String("message", msgStr),
+		return false
+	}
+
+	return c.handleMessageError(ctx, dlq, m, err)
+}
+
+func (c *Consumer) commit(ctx context.Context, msgs ...kafka.Message) {
+	if len(msgs) == 0 {
+		return
+	}
+
+	if err := c.reader.CommitMessages(ctx, msgs...); err != nil {
+		c.logger.Error("backend/internal/pkg/kafka/consumer.go, ошибка коммита оффсета",
+			zap.Int64("offset", msgs[len(msgs)-1].Offset),
 			zap.Error(err),
 		)
-	case strings.Contains(errMsg, "ошибка валидации"):
-		c.logger.Warn("ошибка валидации",
-			zap.Int64("offset", m.Offset),
-			zap.String("message", msgStr),
+	}
+}
+
+func (c *Consumer) handleWithRetry(ctx context.Context, m kafka.Message) error {
+	rt := c.router.match(m)
+	return c.withRetry(ctx, rt.Retry, m.Offset, func() error {
+		return rt.Handler.HandleMessage(ctx, m)
+	})
+}
+
+func (c *Consumer) withRetry(ctx context.Context, retry RetryPolicy, offset int64, handle func() error) error {
+	maxAttempts := retry.attempts()
+
+	for attempt := 1; ; attempt++ {
+		err := handle()
+		if err == nil || !apperrors.IsRetryable(err) || attempt >= maxAttempts {
+			return err
+		}
+
+		delay := retry.Backoff(attempt)
+		c.logger.Warn("временная ошибка обработки сообщения, повтор",
+			zap.Int64("offset", offset),
+			zap.Int("attempt", attempt),
+			zap.Int("max_attempts", maxAttempts),
+			zap.Duration("delay", delay),
 			zap.Error(err),
 		)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+func (c *Consumer) handleMessageError(ctx context.Context, dlq *DeadLetterQueue, m kafka.Message, err error) bool {
+	errClass := apperrors.CodeOf(err)
+	fields := []zap.Field{
+		zap.String("topic", m.Topic),
+		zap.Int64("offset", m.Offset),
+		zap.String("message", string(m.Value)),
+		zap.String("error_class", string(errClass)),
+		zap.Error(err),
+	}
+
+	switch errClass {
+	case apperrors.CodeInvalidJSON:
+		c.logger.Warn("неправильный json", fields...)
+	case apperrors.CodeEmptyOrder:
+		c.logger.Warn("получен пустой заказ", fields...)
+	case apperrors.CodeValidation:
+		c.logger.Warn("ошибка валидации", fields...)
+	case apperrors.CodeUnknownSchema, apperrors.CodeUnknownEvent, apperrors.CodeInvalidMessage, apperrors.CodeContentType:
+		c.logger.Warn("сообщение неизвестного формата", fields...)
+	case apperrors.CodeUnknown:
+		c.logger.Error("неожиданная ошибка при чтении сообщения", fields...)
 	default:
-		c.logger.Error("неожиданная ошибка при чтении сообщения",
+		c.logger.Warn("ошибка при обработке события", fields...)
+	}
+
+	if dlq == nil {
+		return true
+	}
+
+	if err = dlq.Publish(ctx, m, string(errClass), err); err != nil {
+		c.logger.Error("backend/internal/pkg/kafka/consumer.go, ошибка отправки сообщения в dlq, остановка консьюмера",
 			zap.Int64("offset", m.Offset),
-			zap.String("message", msgStr),
 			zap.Error(err),
 		)
+		return false
 	}
+
+	c.logger.Info("сообщение отправлено в dlq",
+		zap.Int64("offset", m.Offset),
+		zap.String("error_class", string(errClass)),
+	)
+
+	return true
 }
 
 func (c *Consumer) Close() error {
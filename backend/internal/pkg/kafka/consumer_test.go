@@ -0,0 +1,206 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+	"github.com/avraam311/order-service/backend/internal/pkg/kafka/kafkatest"
+)
+
+type stubReader struct {
+	mu        sync.Mutex
+	messages  []kafka.Message
+	fetched   int
+	committed []int64
+	cancel    context.CancelFunc
+	// block заставляет ридер после последнего сообщения ждать отмены контекста,
+	// как настоящий брокер, а отменять тест после коммита всех сообщений.
+	block bool
+}
+
+func (r *stubReader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	r.mu.Lock()
+
+	if r.fetched == len(r.messages) {
+		r.mu.Unlock()
+		if r.block {
+			<-ctx.Done()
+			return kafka.Message{}, ctx.Err()
+		}
+
+		r.cancel()
+		return kafka.Message{}, context.Canceled
+	}
+
+	m := r.messages[r.fetched]
+	r.fetched++
+	r.mu.Unlock()
+
+	return m, nil
+}
+
+func (r *stubReader) CommitMessages(_ context.Context, msgs ...kafka.Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, m := range msgs {
+		r.committed = append(r.committed, m.Offset)
+	}
+
+	if r.block && len(r.committed) == len(r.messages) {
+		r.cancel()
+	}
+
+	return nil
+}
+
+func (r *stubReader) Config() kafka.ReaderConfig {
+	return kafka.ReaderConfig{Topic: "order-service", GroupID: "test"}
+}
+
+func (r *stubReader) Close() error {
+	return nil
+}
+
+type stubHandler struct {
+	mu     sync.Mutex
+	errs   map[string][]error
+	called map[string]int
+}
+
+func (h *stubHandler) HandleMessage(_ context.Context, m kafka.Message) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	key := string(m.Value)
+	call := h.called[key]
+	h.called[key]++
+
+	errs := h.errs[key]
+	if call < len(errs) {
+		return errs[call]
+	}
+
+	return nil
+}
+
+func TestConsumer_ConsumeMessage_Commits(t *testing.T) {
+	retryableErr := fmt.Errorf("ошибка создания заказа: %w: %w", apperrors.ErrInsertOrder, apperrors.ErrDBConnection)
+	permanentErr := fmt.Errorf("%w: unexpected EOF", apperrors.ErrInvalidJSON)
+
+	tests := []struct {
+		name          string
+		handlerErrs   map[string][]error
+		withDLQ       bool
+		dlqErr        error
+		wantCommitted []int64
+		wantFetched   int
+		wantDLQ       int
+		wantCalls     map[string]int
+	}{
+		{
+			name:          "успешная обработка коммитит оффсеты",
+			wantCommitted: []int64{0, 1},
+			wantFetched:   2,
+		},
+		{
+			name:          "постоянная ошибка без dlq пропускается",
+			handlerErrs:   map[string][]error{"a": {permanentErr}},
+			wantCommitted: []int64{0, 1},
+			wantFetched:   2,
+		},
+		{
+			name:          "постоянная ошибка коммитится после dlq",
+			handlerErrs:   map[string][]error{"a": {permanentErr}},
+			withDLQ:       true,
+			wantCommitted: []int64{0, 1},
+			wantFetched:   2,
+			wantDLQ:       1,
+		},
+		{
+			name:          "ошибка dlq не коммитит и останавливает консьюмер",
+			handlerErrs:   map[string][]error{"a": {permanentErr}},
+			withDLQ:       true,
+			dlqErr:        errors.New("broker down"),
+			wantCommitted: nil,
+			wantFetched:   1,
+		},
+		{
+			name:          "повторная доставка коммитится без dlq",
+			handlerErrs:   map[string][]error{"a": {fmt.Errorf("ошибка создания заказа: %w", apperrors.ErrOrderDuplicate)}},
+			withDLQ:       true,
+			wantCommitted: []int64{0, 1},
+			wantFetched:   2,
+			wantDLQ:       0,
+		},
+		{
+			name:          "временная ошибка повторяется до успеха",
+			handlerErrs:   map[string][]error{"a": {retryableErr, retryableErr}},
+			wantCommitted: []int64{0, 1},
+			wantFetched:   2,
+			wantCalls:     map[string]int{"a": 3, "b": 1},
+		},
+		{
+			name:          "исчерпанные попытки без dlq не коммитятся",
+			handlerErrs:   map[string][]error{"a": {retryableErr, retryableErr, retryableErr}},
+			wantCommitted: nil,
+			wantFetched:   1,
+			wantCalls:     map[string]int{"a": 3},
+		},
+		{
+			name:          "исчерпанные попытки уходят в dlq и коммитятся",
+			handlerErrs:   map[string][]error{"a": {retryableErr, retryableErr, retryableErr}},
+			withDLQ:       true,
+			wantCommitted: []int64{0, 1},
+			wantFetched:   2,
+			wantDLQ:       1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			reader := &stubReader{
+				messages: []kafka.Message{
+					{Topic: "order-service", Offset: 0, Value: []byte("a")},
+					{Topic: "order-service", Offset: 1, Value: []byte("b")},
+				},
+				cancel: cancel,
+			}
+			handler := &stubHandler{errs: tt.handlerErrs, called: map[string]int{}}
+
+			var writer *kafkatest.Writer
+			var dlq *DeadLetterQueue
+			if tt.withDLQ {
+				writer = &kafkatest.Writer{Err: tt.dlqErr}
+				dlq = NewDeadLetterQueue(writer)
+			}
+
+			c := NewConsumer(reader, zap.NewNop(), handler, dlq, RetryPolicy{MaxAttempts: 3})
+
+			var wg sync.WaitGroup
+			wg.Add(1)
+			c.ConsumeMessage(ctx, &wg)
+			wg.Wait()
+
+			assert.Equal(t, tt.wantCommitted, reader.committed)
+			assert.Equal(t, tt.wantFetched, reader.fetched)
+			if writer != nil {
+				assert.Len(t, writer.Messages(), tt.wantDLQ)
+			}
+			for key, calls := range tt.wantCalls {
+				assert.Equal(t, calls, handler.called[key], key)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+)
+
+const (
+	HeaderOriginalTopic     = "x-original-topic"
+	HeaderOriginalPartition = "x-original-partition"
+	HeaderOriginalOffset    = "x-original-offset"
+	HeaderErrorClass        = "x-error-class"
+	HeaderErrorMessage      = "x-error-message"
+	HeaderFailedAt          = "x-failed-at"
+)
+
+type messageWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+type DeadLetterQueue struct {
+	writer messageWriter
+	now    func() time.Time
+}
+
+func NewWriter(topic string, brokers []string) *kafka.Writer {
+	return &kafka.Writer{
+		Addr:                   kafka.TCP(brokers...),
+		Topic:                  topic,
+		Balancer:               &kafka.Hash{},
+		RequiredAcks:           kafka.RequireAll,
+		AllowAutoTopicCreation: true,
+	}
+}
+
+func NewDeadLetterQueue(w messageWriter) *DeadLetterQueue {
+	return &DeadLetterQueue{
+		writer: w,
+		now:    time.Now,
+	}
+}
+
+func (d *DeadLetterQueue) Publish(ctx context.Context, m kafka.Message, errClass string, cause error) error {
+	headers := make([]kafka.Header, 0, len(m.Headers)+6)
+	headers = append(headers, m.Headers...)
+	headers = append(headers,
+		kafka.Header{Key: HeaderOriginalTopic, Value: []byte(m.Topic)},
+		kafka.Header{Key: HeaderOriginalPartition, Value: []byte(strconv.Itoa(m.Partition))},
+		kafka.Header{Key: HeaderOriginalOffset, Value: []byte(strconv.FormatInt(m.Offset, 10))},
+		kafka.Header{Key: HeaderErrorClass, Value: []byte(errClass)},
+		kafka.Header{Key: HeaderErrorMessage, Value: []byte(cause.Error())},
+		kafka.Header{Key: HeaderFailedAt, Value: []byte(d.now().UTC().Format(time.RFC3339Nano))},
+	)
+
+	err := d.writer.WriteMessages(ctx, kafka.Message{
+		Key:     m.Key,
+		Value:   m.Value,
+		Headers: headers,
+	})
+	if err != nil {
+		return fmt.Errorf("backend/internal/pkg/kafka/dlq.go: %w: %w", apperrors.ErrDLQPublish, err)
+	}
+
+	return nil
+}
+
+func (d *DeadLetterQueue) Close() error {
+	return d.writer.Close()
+}
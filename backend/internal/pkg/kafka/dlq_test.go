@@ -0,0 +1,72 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+	"github.com/avraam311/order-service/backend/internal/pkg/kafka/kafkatest"
+)
+
+func TestDeadLetterQueue_Publish(t *testing.T) {
+	failedAt := time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		writerErr error
+		wantErr   error
+	}{
+		{
+			name: "сообщение отправлено с заголовками",
+		},
+		{
+			name:      "ошибка записи",
+			writerErr: errors.New("broker down"),
+			wantErr:   apperrors.ErrDLQPublish,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &kafkatest.Writer{Err: tt.writerErr}
+			dlq := NewDeadLetterQueue(w)
+			dlq.now = func() time.Time { return failedAt }
+
+			m := kafka.Message{
+				Topic:     "order-service",
+				Partition: 2,
+				Offset:    42,
+				Key:       []byte("key"),
+				Value:     []byte(`{"order_uid":`),
+				Headers:   []kafka.Header{{Key: "trace-id", Value: []byte("abc")}},
+			}
+
+			err := dlq.Publish(context.Background(), m, string(apperrors.CodeInvalidJSON), errors.New("неправильный json: unexpected EOF"))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, w.Messages())
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, w.Messages(), 1)
+
+			got := w.Messages()[0]
+			assert.Equal(t, m.Key, got.Key)
+			assert.Equal(t, m.Value, got.Value)
+			assert.Equal(t, "abc", kafkatest.HeaderValue(got, "trace-id"))
+			assert.Equal(t, "order-service", kafkatest.HeaderValue(got, HeaderOriginalTopic))
+			assert.Equal(t, "2", kafkatest.HeaderValue(got, HeaderOriginalPartition))
+			assert.Equal(t, "42", kafkatest.HeaderValue(got, HeaderOriginalOffset))
+			assert.Equal(t, string(apperrors.CodeInvalidJSON), kafkatest.HeaderValue(got, HeaderErrorClass))
+			assert.Equal(t, "неправильный json: unexpected EOF", kafkatest.HeaderValue(got, HeaderErrorMessage))
+			assert.Equal(t, failedAt.Format(time.RFC3339Nano), kafkatest.HeaderValue(got, HeaderFailedAt))
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package envelope
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+)
+
+const EventOrderCreated = "order.created"
+
+type Envelope struct {
+	EventType     string          `json:"event_type"`
+	SchemaVersion int             `json:"schema_version"`
+	ProducerID    string          `json:"producer_id"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
+// Decode разбирает конверт сообщения. Сообщение без конверта (голый заказ,
+// как до появления конвертов) считается order.created текущей версии.
+func Decode(msg []byte) (Envelope, error) {
+	var probe struct {
+		SchemaVersion *int            `json:"schema_version"`
+		Payload       json.RawMessage `json:"payload"`
+	}
+	if err := json.Unmarshal(msg, &probe); err != nil {
+		return Envelope{}, fmt.Errorf("%w: %w", apperrors.ErrInvalidJSON, err)
+	}
+
+	if probe.SchemaVersion == nil && probe.Payload == nil {
+		return Envelope{
+			EventType:     EventOrderCreated,
+			SchemaVersion: CurrentOrderVersion,
+			Payload:       bytes.Clone(msg),
+		}, nil
+	}
+
+	var env Envelope
+	if err := json.Unmarshal(msg, &env); err != nil {
+		return Envelope{}, fmt.Errorf("%w: %w", apperrors.ErrInvalidJSON, err)
+	}
+
+	return env, nil
+}
+
+func New(eventType string, version int, producerID string, payload any) (Envelope, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return Envelope{}, fmt.Errorf("backend/internal/pkg/kafka/envelope/envelope.go, кодирование payload: %w", err)
+	}
+
+	return Envelope{
+		EventType:     eventType,
+		SchemaVersion: version,
+		ProducerID:    producerID,
+		OccurredAt:    time.Now().UTC(),
+		Payload:       raw,
+	}, nil
+}
@@ -0,0 +1,170 @@
+package envelope
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/models/modeltest"
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+)
+
+func envelopeJSON(t *testing.T, eventType string, version int, payload any) []byte {
+	t.Helper()
+
+	raw, err := json.Marshal(payload)
+	require.NoError(t, err)
+
+	msg, err := json.Marshal(Envelope{
+		EventType:     eventType,
+		SchemaVersion: version,
+		ProducerID:    "test-producer",
+		OccurredAt:    time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC),
+		Payload:       raw,
+	})
+	require.NoError(t, err)
+
+	return msg
+}
+
+func TestDecodeOrder(t *testing.T) {
+	want := *modeltest.Order(t)
+	bare, err := json.Marshal(want)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		msg     []byte
+		wantErr error
+	}{
+		{
+			name: "текущая версия",
+			msg:  envelopeJSON(t, EventOrderCreated, CurrentOrderVersion, want),
+		},
+		{
+			name: "сообщение без конверта читается как текущая версия",
+			msg:  bare,
+		},
+		{
+			name:    "версия новее текущей",
+			msg:     envelopeJSON(t, EventOrderCreated, CurrentOrderVersion+1, want),
+			wantErr: apperrors.ErrUnknownSchemaVersion,
+		},
+		{
+			name:    "версия не указана",
+			msg:     []byte(`{"event_type":"order.created","payload":{}}`),
+			wantErr: apperrors.ErrUnknownSchemaVersion,
+		},
+		{
+			name:    "другой тип события",
+			msg:     envelopeJSON(t, "order.cancelled", CurrentOrderVersion, want),
+			wantErr: apperrors.ErrUnknownEventType,
+		},
+		{
+			name:    "пустой payload",
+			msg:     []byte(`{"event_type":"order.created","schema_version":1,"payload":null}`),
+			wantErr: apperrors.ErrEmptyOrder,
+		},
+		{
+			name:    "неправильный json",
+			msg:     []byte(`{"event_type":`),
+			wantErr: apperrors.ErrInvalidJSON,
+		},
+		{
+			name:    "пустое сообщение",
+			msg:     []byte(`null`),
+			wantErr: apperrors.ErrEmptyOrder,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env, err := Decode(tt.msg)
+			if err == nil {
+				var order *models.Order
+				order, err = DecodeOrder(env)
+				if tt.wantErr == nil {
+					require.NoError(t, err)
+					assert.Equal(t, want, *order)
+					return
+				}
+			}
+
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestDecode_KeepsMetadata(t *testing.T) {
+	env, err := Decode(envelopeJSON(t, EventOrderCreated, CurrentOrderVersion, *modeltest.Order(t)))
+	require.NoError(t, err)
+
+	assert.Equal(t, EventOrderCreated, env.EventType)
+	assert.Equal(t, CurrentOrderVersion, env.SchemaVersion)
+	assert.Equal(t, "test-producer", env.ProducerID)
+	assert.Equal(t, time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC), env.OccurredAt)
+}
+
+func TestDecodeEvent(t *testing.T) {
+	orderID := uuid.New()
+	payload := StatusChanged{OrderID: orderID, Status: models.StatusPaid, ChangedBy: "billing"}
+
+	tests := []struct {
+		name      string
+		msg       []byte
+		eventType string
+		wantErr   error
+	}{
+		{
+			name:      "смена статуса",
+			msg:       envelopeJSON(t, EventOrderStatusChanged, CurrentEventVersion, payload),
+			eventType: EventOrderStatusChanged,
+		},
+		{
+			name:      "другой тип события",
+			msg:       envelopeJSON(t, EventOrderCancelled, CurrentEventVersion, payload),
+			eventType: EventOrderStatusChanged,
+			wantErr:   apperrors.ErrUnknownEventType,
+		},
+		{
+			name:      "неизвестная версия",
+			msg:       envelopeJSON(t, EventOrderStatusChanged, CurrentEventVersion+1, payload),
+			eventType: EventOrderStatusChanged,
+			wantErr:   apperrors.ErrUnknownSchemaVersion,
+		},
+		{
+			name:      "пустой payload",
+			msg:       []byte(`{"event_type":"order.status_changed","schema_version":1,"payload":null}`),
+			eventType: EventOrderStatusChanged,
+			wantErr:   apperrors.ErrEmptyOrder,
+		},
+		{
+			name:      "сообщение без конверта",
+			msg:       []byte(`{"order_uid":"` + orderID.String() + `","status":"paid"}`),
+			eventType: EventOrderStatusChanged,
+			wantErr:   apperrors.ErrUnknownEventType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env, err := Decode(tt.msg)
+			require.NoError(t, err)
+
+			var got StatusChanged
+			err = DecodeEvent(env, tt.eventType, &got)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, payload, got)
+		})
+	}
+}
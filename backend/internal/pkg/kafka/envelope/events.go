@@ -0,0 +1,60 @@
+package envelope
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+)
+
+const (
+	EventOrderStatusChanged = "order.status_changed"
+	EventOrderCancelled     = "order.cancelled"
+	EventPaymentUpdated     = "payment.updated"
+)
+
+// CurrentEventVersion — версия payload событий, кроме order.created.
+const CurrentEventVersion = 1
+
+type StatusChanged struct {
+	OrderID   uuid.UUID          `json:"order_uid" validate:"required"`
+	Status    models.OrderStatus `json:"status" validate:"required"`
+	ChangedBy string             `json:"changed_by" validate:"required"`
+	Reason    string             `json:"reason,omitempty"`
+}
+
+type OrderCancelled struct {
+	OrderID   uuid.UUID `json:"order_uid" validate:"required"`
+	ChangedBy string    `json:"changed_by" validate:"required"`
+	Reason    string    `json:"reason,omitempty"`
+}
+
+type PaymentUpdated struct {
+	OrderID uuid.UUID      `json:"order_uid" validate:"required"`
+	Payment models.Payment `json:"payment" validate:"required"`
+}
+
+// DecodeEvent проверяет тип и версию события и разбирает payload в v.
+func DecodeEvent(env Envelope, eventType string, v any) error {
+	if env.EventType != eventType {
+		return fmt.Errorf("event_type %q: %w", env.EventType, apperrors.ErrUnknownEventType)
+	}
+
+	if env.SchemaVersion != CurrentEventVersion {
+		return fmt.Errorf("schema_version %d: %w", env.SchemaVersion, apperrors.ErrUnknownSchemaVersion)
+	}
+
+	if len(env.Payload) == 0 || bytes.Equal(env.Payload, []byte("null")) {
+		return fmt.Errorf("%s: %w", eventType, apperrors.ErrEmptyOrder)
+	}
+
+	if err := json.Unmarshal(env.Payload, v); err != nil {
+		return fmt.Errorf("%w: %w", apperrors.ErrInvalidJSON, err)
+	}
+
+	return nil
+}
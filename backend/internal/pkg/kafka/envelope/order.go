@@ -0,0 +1,50 @@
+package envelope
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+)
+
+// CurrentOrderVersion — версия payload, совпадающая с models.Order.
+const CurrentOrderVersion = 1
+
+type upcaster func(payload json.RawMessage) (json.RawMessage, error)
+
+// orderUpcasters[v] переводит payload версии v в версию v+1. Пока версия одна,
+// поднимать нечего: при изменении models.Order сюда добавляется перевод из
+// предыдущей версии, а CurrentOrderVersion увеличивается.
+var orderUpcasters = map[int]upcaster{}
+
+// DecodeOrder поднимает payload order.created до текущей версии и разбирает
+// его в models.Order. Неизвестная версия не разбирается вовсе.
+func DecodeOrder(env Envelope) (*models.Order, error) {
+	if env.EventType != EventOrderCreated {
+		return nil, fmt.Errorf("event_type %q: %w", env.EventType, apperrors.ErrUnknownEventType)
+	}
+
+	if env.SchemaVersion < 1 || env.SchemaVersion > CurrentOrderVersion {
+		return nil, fmt.Errorf("schema_version %d: %w", env.SchemaVersion, apperrors.ErrUnknownSchemaVersion)
+	}
+
+	payload := env.Payload
+	for v := env.SchemaVersion; v < CurrentOrderVersion; v++ {
+		var err error
+		if payload, err = orderUpcasters[v](payload); err != nil {
+			return nil, fmt.Errorf("schema_version %d: %w", v, err)
+		}
+	}
+
+	var order *models.Order
+	if err := json.Unmarshal(payload, &order); err != nil {
+		return nil, fmt.Errorf("%w: %w", apperrors.ErrInvalidJSON, err)
+	}
+
+	if order == nil {
+		return nil, apperrors.ErrEmptyOrder
+	}
+
+	return order, nil
+}
@@ -2,58 +2,99 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/segmentio/kafka-go"
 
 	"github.com/avraam311/order-service/backend/internal/models"
-)
-
-var (
-	ErrCreateOrder = errors.New("ошибка создания заказа")
-	ErrInvalidJSON = errors.New("неправильный json")
-	ErrValidation  = errors.New("ошибка валидации")
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+	"github.com/avraam311/order-service/backend/internal/pkg/kafka/codec"
 )
 
 type orderService interface {
 	SaveOrder(ctx context.Context, order *models.Order) (uuid.UUID, error)
+	SaveOrders(ctx context.Context, orders []*models.Order) ([]error, error)
 }
 
 type validator interface {
 	Validate(i interface{}) error
 }
 
+type orderDecoder interface {
+	Decode(contentType string, msg []byte) (*models.Order, error)
+}
+
 type CreateHandler struct {
 	validator    validator
+	decoder      orderDecoder
 	orderService orderService
 }
 
-func NewCreateHandler(v validator, s orderService) *CreateHandler {
+func NewCreateHandler(v validator, d orderDecoder, s orderService) *CreateHandler {
 	return &CreateHandler{
 		validator:    v,
+		decoder:      d,
 		orderService: s,
 	}
 }
 
-func (h *CreateHandler) HandleMessage(ctx context.Context, msg []byte) error {
-	var order *models.Order
-	if err := json.Unmarshal(msg, &order); err != nil {
-		return fmt.Errorf("неправильный json: %w", err)
+func (h *CreateHandler) HandleMessage(ctx context.Context, m kafka.Message) error {
+	order, err := h.decode(m)
+	if err != nil {
+		return err
 	}
 
-	if order == nil {
-		return errors.New("пустой заказ")
+	if _, err = h.orderService.SaveOrder(ctx, order); err != nil {
+		return fmt.Errorf("ошибка создания заказа: %w", err)
 	}
 
-	if err := h.validator.Validate(order); err != nil {
-		return fmt.Errorf("ошибка валидации: %w", err)
+	return nil
+}
+
+func (h *CreateHandler) HandleBatch(ctx context.Context, msgs []kafka.Message) ([]error, error) {
+	results := make([]error, len(msgs))
+	orders := make([]*models.Order, 0, len(msgs))
+	positions := make([]int, 0, len(msgs))
+
+	for i, msg := range msgs {
+		order, err := h.decode(msg)
+		if err != nil {
+			results[i] = err
+			continue
+		}
+
+		orders = append(orders, order)
+		positions = append(positions, i)
 	}
 
-	if _, err := h.orderService.SaveOrder(ctx, order); err != nil {
-		return fmt.Errorf("ошибка создания заказа: %w", err)
+	if len(orders) == 0 {
+		return results, nil
 	}
 
-	return nil
+	saved, err := h.orderService.SaveOrders(ctx, orders)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка создания заказов: %w", err)
+	}
+
+	for j, err := range saved {
+		if err != nil {
+			results[positions[j]] = fmt.Errorf("ошибка создания заказа: %w", err)
+		}
+	}
+
+	return results, nil
+}
+
+func (h *CreateHandler) decode(m kafka.Message) (*models.Order, error) {
+	order, err := h.decoder.Decode(codec.ContentTypeOf(m), m.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.validator.Validate(order); err != nil {
+		return nil, fmt.Errorf("%w: %w", apperrors.ErrValidation, err)
+	}
+
+	return order, nil
 }
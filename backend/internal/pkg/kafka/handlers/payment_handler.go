@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/segmentio/kafka-go"
+
+	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+	"github.com/avraam311/order-service/backend/internal/pkg/kafka/envelope"
+)
+
+type paymentUpdater interface {
+	UpdatePayment(ctx context.Context, orderID uuid.UUID, payment models.Payment) error
+}
+
+type PaymentHandler struct {
+	validator validator
+	service   paymentUpdater
+}
+
+func NewPaymentHandler(v validator, s paymentUpdater) *PaymentHandler {
+	return &PaymentHandler{
+		validator: v,
+		service:   s,
+	}
+}
+
+func (h *PaymentHandler) HandleMessage(ctx context.Context, m kafka.Message) error {
+	env, err := envelope.Decode(m.Value)
+	if err != nil {
+		return err
+	}
+
+	var ev envelope.PaymentUpdated
+	if err = envelope.DecodeEvent(env, envelope.EventPaymentUpdated, &ev); err != nil {
+		return err
+	}
+
+	if err = h.validator.Validate(ev); err != nil {
+		return fmt.Errorf("%w: %w", apperrors.ErrValidation, err)
+	}
+
+	if err = h.service.UpdatePayment(ctx, ev.OrderID, ev.Payment); err != nil {
+		return fmt.Errorf("ошибка изменения оплаты заказа: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mock_handlers "github.com/avraam311/order-service/backend/internal/mocks/handlers"
+	"github.com/avraam311/order-service/backend/internal/models/modeltest"
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+	"github.com/avraam311/order-service/backend/internal/pkg/kafka/envelope"
+	goValidator "github.com/avraam311/order-service/backend/internal/pkg/validator"
+)
+
+func TestPaymentHandler_HandleMessage(t *testing.T) {
+	orderID := uuid.New()
+	payment := modeltest.Order(t).Payment
+	paymentMessage := func(t *testing.T) kafka.Message {
+		return eventMessage(t, envelope.EventPaymentUpdated, envelope.CurrentEventVersion, envelope.PaymentUpdated{OrderID: orderID, Payment: payment})
+	}
+
+	tests := []struct {
+		name       string
+		msg        func(t *testing.T) kafka.Message
+		expectCall bool
+		serviceErr error
+		wantErr    error
+	}{
+		{
+			name:       "оплата обновлена",
+			msg:        paymentMessage,
+			expectCall: true,
+		},
+		{
+			name: "неправильный json",
+			msg: func(*testing.T) kafka.Message {
+				return kafka.Message{Value: []byte(`{"payload":`)}
+			},
+			wantErr: apperrors.ErrInvalidJSON,
+		},
+		{
+			name: "чужой тип события",
+			msg: func(t *testing.T) kafka.Message {
+				return eventMessage(t, envelope.EventOrderStatusChanged, envelope.CurrentEventVersion, envelope.PaymentUpdated{OrderID: orderID, Payment: payment})
+			},
+			wantErr: apperrors.ErrUnknownEventType,
+		},
+		{
+			name: "невалидная оплата",
+			msg: func(t *testing.T) kafka.Message {
+				broken := payment
+				broken.Currency = ""
+				return eventMessage(t, envelope.EventPaymentUpdated, envelope.CurrentEventVersion, envelope.PaymentUpdated{OrderID: orderID, Payment: broken})
+			},
+			wantErr: apperrors.ErrValidation,
+		},
+		{
+			name:       "заказ не найден",
+			msg:        paymentMessage,
+			expectCall: true,
+			serviceErr: apperrors.ErrOrderNotFound,
+			wantErr:    apperrors.ErrOrderNotFound,
+		},
+		{
+			name:       "ошибка соединения с бд",
+			msg:        paymentMessage,
+			expectCall: true,
+			serviceErr: apperrors.ErrDBConnection,
+			wantErr:    apperrors.ErrDBConnection,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			svc := mock_handlers.NewMockpaymentUpdater(ctrl)
+			if tt.expectCall {
+				svc.EXPECT().UpdatePayment(gomock.Any(), orderID, payment).Return(tt.serviceErr)
+			}
+
+			err := NewPaymentHandler(goValidator.New(), svc).HandleMessage(context.Background(), tt.msg(t))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, apperrors.IsRetryable(tt.wantErr), apperrors.IsRetryable(err))
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
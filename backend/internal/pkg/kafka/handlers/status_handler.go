@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/segmentio/kafka-go"
+
+	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+	"github.com/avraam311/order-service/backend/internal/pkg/kafka/envelope"
+)
+
+type statusChanger interface {
+	ChangeStatus(ctx context.Context, orderID uuid.UUID, to models.OrderStatus, changedBy, reason string) (*models.StatusChange, error)
+}
+
+// StatusHandler обрабатывает order.status_changed и order.cancelled: отмена —
+// это смена статуса на cancelled.
+type StatusHandler struct {
+	validator validator
+	service   statusChanger
+	eventType string
+}
+
+func NewStatusChangedHandler(v validator, s statusChanger) *StatusHandler {
+	return &StatusHandler{
+		validator: v,
+		service:   s,
+		eventType: envelope.EventOrderStatusChanged,
+	}
+}
+
+func NewCancelledHandler(v validator, s statusChanger) *StatusHandler {
+	return &StatusHandler{
+		validator: v,
+		service:   s,
+		eventType: envelope.EventOrderCancelled,
+	}
+}
+
+func (h *StatusHandler) HandleMessage(ctx context.Context, m kafka.Message) error {
+	ev, err := h.decode(m)
+	if err != nil {
+		return err
+	}
+
+	if _, err = h.service.ChangeStatus(ctx, ev.OrderID, ev.Status, ev.ChangedBy, ev.Reason); err != nil {
+		return fmt.Errorf("ошибка изменения статуса заказа: %w", err)
+	}
+
+	return nil
+}
+
+func (h *StatusHandler) decode(m kafka.Message) (envelope.StatusChanged, error) {
+	var ev envelope.StatusChanged
+
+	env, err := envelope.Decode(m.Value)
+	if err != nil {
+		return ev, err
+	}
+
+	if h.eventType == envelope.EventOrderCancelled {
+		var cancelled envelope.OrderCancelled
+		if err = envelope.DecodeEvent(env, h.eventType, &cancelled); err != nil {
+			return ev, err
+		}
+
+		ev = envelope.StatusChanged{
+			OrderID:   cancelled.OrderID,
+			Status:    models.StatusCancelled,
+			ChangedBy: cancelled.ChangedBy,
+			Reason:    cancelled.Reason,
+		}
+	} else if err = envelope.DecodeEvent(env, h.eventType, &ev); err != nil {
+		return ev, err
+	}
+
+	if err = h.validator.Validate(ev); err != nil {
+		return ev, fmt.Errorf("%w: %w", apperrors.ErrValidation, err)
+	}
+
+	return ev, nil
+}
@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mock_handlers "github.com/avraam311/order-service/backend/internal/mocks/handlers"
+	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+	"github.com/avraam311/order-service/backend/internal/pkg/kafka/envelope"
+	goValidator "github.com/avraam311/order-service/backend/internal/pkg/validator"
+)
+
+func eventMessage(t *testing.T, eventType string, version int, payload any) kafka.Message {
+	t.Helper()
+
+	env, err := envelope.New(eventType, version, "test", payload)
+	require.NoError(t, err)
+
+	value, err := json.Marshal(env)
+	require.NoError(t, err)
+
+	return kafka.Message{Value: value}
+}
+
+func TestStatusHandler_HandleMessage(t *testing.T) {
+	orderID := uuid.New()
+
+	tests := []struct {
+		name       string
+		cancelled  bool
+		msg        func(t *testing.T) kafka.Message
+		expectCall bool
+		wantStatus models.OrderStatus
+		serviceErr error
+		wantErr    error
+	}{
+		{
+			name: "статус изменен",
+			msg: func(t *testing.T) kafka.Message {
+				return eventMessage(t, envelope.EventOrderStatusChanged, envelope.CurrentEventVersion, envelope.StatusChanged{OrderID: orderID, Status: models.StatusPaid, ChangedBy: "payments"})
+			},
+			expectCall: true,
+			wantStatus: models.StatusPaid,
+		},
+		{
+			name:      "отмена заказа",
+			cancelled: true,
+			msg: func(t *testing.T) kafka.Message {
+				return eventMessage(t, envelope.EventOrderCancelled, envelope.CurrentEventVersion, envelope.OrderCancelled{OrderID: orderID, ChangedBy: "payments", Reason: "передумал"})
+			},
+			expectCall: true,
+			wantStatus: models.StatusCancelled,
+		},
+		{
+			name: "неправильный json",
+			msg: func(*testing.T) kafka.Message {
+				return kafka.Message{Value: []byte(`{"event_type":`)}
+			},
+			wantErr: apperrors.ErrInvalidJSON,
+		},
+		{
+			name: "неизвестная версия",
+			msg: func(t *testing.T) kafka.Message {
+				return eventMessage(t, envelope.EventOrderStatusChanged, 7, envelope.StatusChanged{OrderID: orderID, Status: models.StatusPaid})
+			},
+			wantErr: apperrors.ErrUnknownSchemaVersion,
+		},
+		{
+			name: "чужой тип события",
+			msg: func(t *testing.T) kafka.Message {
+				return eventMessage(t, envelope.EventPaymentUpdated, envelope.CurrentEventVersion, envelope.StatusChanged{OrderID: orderID, Status: models.StatusPaid})
+			},
+			wantErr: apperrors.ErrUnknownEventType,
+		},
+		{
+			name: "не указан статус",
+			msg: func(t *testing.T) kafka.Message {
+				return eventMessage(t, envelope.EventOrderStatusChanged, envelope.CurrentEventVersion, envelope.StatusChanged{OrderID: orderID, ChangedBy: "payments"})
+			},
+			wantErr: apperrors.ErrValidation,
+		},
+		{
+			name: "не указан changed_by",
+			msg: func(t *testing.T) kafka.Message {
+				return eventMessage(t, envelope.EventOrderStatusChanged, envelope.CurrentEventVersion, envelope.StatusChanged{OrderID: orderID, Status: models.StatusPaid})
+			},
+			wantErr: apperrors.ErrValidation,
+		},
+		{
+			name:      "отмена без changed_by",
+			cancelled: true,
+			msg: func(t *testing.T) kafka.Message {
+				return eventMessage(t, envelope.EventOrderCancelled, envelope.CurrentEventVersion, envelope.OrderCancelled{OrderID: orderID, Reason: "передумал"})
+			},
+			wantErr: apperrors.ErrValidation,
+		},
+		{
+			name: "статус изменен параллельно",
+			msg: func(t *testing.T) kafka.Message {
+				return eventMessage(t, envelope.EventOrderStatusChanged, envelope.CurrentEventVersion, envelope.StatusChanged{OrderID: orderID, Status: models.StatusPaid, ChangedBy: "payments"})
+			},
+			expectCall: true,
+			wantStatus: models.StatusPaid,
+			serviceErr: apperrors.ErrStatusConflict,
+			wantErr:    apperrors.ErrStatusConflict,
+		},
+		{
+			name: "заказ не найден",
+			msg: func(t *testing.T) kafka.Message {
+				return eventMessage(t, envelope.EventOrderStatusChanged, envelope.CurrentEventVersion, envelope.StatusChanged{OrderID: orderID, Status: models.StatusPaid, ChangedBy: "payments"})
+			},
+			expectCall: true,
+			wantStatus: models.StatusPaid,
+			serviceErr: apperrors.ErrOrderNotFound,
+			wantErr:    apperrors.ErrOrderNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			svc := mock_handlers.NewMockstatusChanger(ctrl)
+			if tt.expectCall {
+				svc.EXPECT().ChangeStatus(gomock.Any(), orderID, tt.wantStatus, gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, id uuid.UUID, to models.OrderStatus, by, reason string) (*models.StatusChange, error) {
+						if tt.serviceErr != nil {
+							return nil, tt.serviceErr
+						}
+						return &models.StatusChange{OrderID: id, From: models.StatusPaid, To: to, ChangedBy: by, Reason: reason, ChangedAt: time.Now()}, nil
+					})
+			}
+
+			h := NewStatusChangedHandler(goValidator.New(), svc)
+			if tt.cancelled {
+				h = NewCancelledHandler(goValidator.New(), svc)
+			}
+
+			err := h.HandleMessage(context.Background(), tt.msg(t))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, apperrors.IsRetryable(tt.wantErr), apperrors.IsRetryable(err))
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
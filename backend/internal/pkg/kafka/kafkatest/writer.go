@@ -0,0 +1,58 @@
+// Package kafkatest содержит заглушки kafka для тестов.
+package kafkatest
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Writer запоминает записанные сообщения. Если задан Err, запись завершается
+// этой ошибкой и ничего не сохраняет.
+type Writer struct {
+	mu       sync.Mutex
+	messages []kafka.Message
+	Err      error
+}
+
+func (w *Writer) WriteMessages(_ context.Context, msgs ...kafka.Message) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.Err != nil {
+		return w.Err
+	}
+
+	w.messages = append(w.messages, msgs...)
+	return nil
+}
+
+func (w *Writer) Close() error {
+	return nil
+}
+
+func (w *Writer) SetErr(err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.Err = err
+}
+
+func (w *Writer) Messages() []kafka.Message {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return slices.Clone(w.messages)
+}
+
+func HeaderValue(m kafka.Message, key string) string {
+	for _, h := range m.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,199 @@
+package kafka
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+const (
+	OrderByPartition = "partition"
+	OrderByKey       = "key"
+
+	workerQueueSize = 64
+)
+
+type PoolPolicy struct {
+	Workers int
+	OrderBy string
+}
+
+// worker выбирает воркер так, чтобы сообщения одной партиции (или одного ключа)
+// всегда попадали в одну очередь и обрабатывались по порядку.
+func (p PoolPolicy) worker(m kafka.Message, workers int) int {
+	if p.OrderBy == OrderByKey && len(m.Key) > 0 {
+		h := fnv.New32a()
+		h.Write(m.Key)
+		return int(h.Sum32() % uint32(workers))
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(m.Topic))
+	return int((h.Sum32() + uint32(m.Partition)) % uint32(workers))
+}
+
+// ConsumeConcurrent обрабатывает сообщения пулом из Workers воркеров. Порядок
+// сохраняется внутри партиции или ключа, а оффсет партиции коммитится только
+// когда обработаны все предыдущие сообщения этой партиции.
+func (c *Consumer) ConsumeConcurrent(ctx context.Context, wg *sync.WaitGroup, policy PoolPolicy) {
+	defer wg.Done()
+	defer c.Close()
+
+	runCtx, stop := context.WithCancel(ctx)
+	defer stop()
+
+	c.start(ctx)
+
+	workers := max(policy.Workers, 1)
+	tracker := newOffsetTracker()
+	queues := make([]chan kafka.Message, workers)
+
+	var workersWG sync.WaitGroup
+	for i := range queues {
+		queues[i] = make(chan kafka.Message, workerQueueSize)
+		workersWG.Add(1)
+		go func(q <-chan kafka.Message) {
+			defer workersWG.Done()
+			c.work(runCtx, stop, q, tracker)
+		}(queues[i])
+	}
+
+	for {
+		m, err := c.reader.FetchMessage(runCtx)
+		if err != nil {
+			if c.fetchStopped(err) {
+				break
+			}
+			continue
+		}
+
+		tracker.track(m)
+		select {
+		case queues[policy.worker(m, workers)] <- m:
+		case <-runCtx.Done():
+		}
+	}
+
+	for _, q := range queues {
+		close(q)
+	}
+	workersWG.Wait()
+
+	c.logger.Info("чтение сообщения завершено", zap.Int("workers", workers))
+}
+
+func (c *Consumer) work(ctx context.Context, stop context.CancelFunc, q <-chan kafka.Message, tracker *offsetTracker) {
+	for m := range q {
+		if ctx.Err() != nil {
+			continue
+		}
+
+		if !c.settle(ctx, m, c.handleWithRetry(ctx, m)) {
+			stop()
+			continue
+		}
+
+		tracker.complete(m, func(ready ...kafka.Message) {
+			c.commit(ctx, ready...)
+		})
+	}
+}
+
+type partitionKey struct {
+	topic     string
+	partition int
+}
+
+type partitionOffsets struct {
+	mu         sync.Mutex
+	pending    []kafka.Message
+	done       map[int64]struct{}
+	ready      []kafka.Message
+	committing bool
+}
+
+// offsetTracker помнит прочитанные, но еще не закоммиченные сообщения каждой
+// партиции в порядке чтения.
+type offsetTracker struct {
+	mu         sync.Mutex
+	partitions map[partitionKey]*partitionOffsets
+}
+
+func newOffsetTracker() *offsetTracker {
+	return &offsetTracker{
+		partitions: make(map[partitionKey]*partitionOffsets),
+	}
+}
+
+func (t *offsetTracker) partition(m kafka.Message, create bool) *partitionOffsets {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := partitionKey{topic: m.Topic, partition: m.Partition}
+	p, ok := t.partitions[key]
+	if !ok && create {
+		p = &partitionOffsets{done: make(map[int64]struct{})}
+		t.partitions[key] = p
+	}
+
+	return p
+}
+
+func (t *offsetTracker) track(m kafka.Message) {
+	p := t.partition(m, true)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.pending = append(p.pending, m)
+}
+
+// complete отмечает сообщение обработанным и передает в commit непрерывный
+// префикс обработанных сообщений партиции. Коммит идет без блокировки, но в
+// каждой партиции одновременно коммитит только один воркер: он забирает все,
+// что накопилось за время коммита, поэтому коммиты не обгоняют друг друга, а
+// остальные воркеры не ждут брокер.
+func (t *offsetTracker) complete(m kafka.Message, commit func(ready ...kafka.Message)) {
+	p := t.partition(m, false)
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	p.done[m.Offset] = struct{}{}
+
+	n := 0
+	for n < len(p.pending) {
+		offset := p.pending[n].Offset
+		if _, ok := p.done[offset]; !ok {
+			break
+		}
+		delete(p.done, offset)
+		n++
+	}
+
+	p.ready = append(p.ready, p.pending[:n]...)
+	p.pending = p.pending[n:]
+
+	if p.committing {
+		p.mu.Unlock()
+		return
+	}
+	p.committing = true
+
+	for len(p.ready) > 0 {
+		ready := p.ready
+		p.ready = nil
+		p.mu.Unlock()
+
+		commit(ready...)
+
+		p.mu.Lock()
+	}
+
+	p.committing = false
+	p.mu.Unlock()
+}
@@ -0,0 +1,240 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+)
+
+type recordingHandler struct {
+	mu        sync.Mutex
+	processed map[string][]string
+	errs      map[string]error
+	inFlight  int
+	maxFlight int
+}
+
+func (h *recordingHandler) HandleMessage(_ context.Context, m kafka.Message) error {
+	key := string(m.Value)
+	group, _, _ := strings.Cut(key, "/")
+
+	h.mu.Lock()
+	h.inFlight++
+	h.maxFlight = max(h.maxFlight, h.inFlight)
+	h.mu.Unlock()
+
+	time.Sleep(5 * time.Millisecond)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.inFlight--
+	h.processed[group] = append(h.processed[group], key)
+
+	return h.errs[key]
+}
+
+func poolMessages(partitions, perPartition int, keyed bool) []kafka.Message {
+	var msgs []kafka.Message
+	for i := 0; i < perPartition; i++ {
+		for p := 0; p < partitions; p++ {
+			group := fmt.Sprintf("p%d", p)
+			m := kafka.Message{
+				Topic:     "order-service",
+				Partition: p,
+				Offset:    int64(p*100 + i),
+				Value:     []byte(fmt.Sprintf("%s/%d", group, i)),
+			}
+			if keyed {
+				group = fmt.Sprintf("k%d", i%2)
+				m.Partition = 0
+				m.Offset = int64(len(msgs))
+				m.Key = []byte(group)
+				m.Value = []byte(fmt.Sprintf("%s/%d", group, len(msgs)))
+			}
+			msgs = append(msgs, m)
+		}
+	}
+
+	return msgs
+}
+
+func TestConsumer_ConsumeConcurrent(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  PoolPolicy
+		keyed   bool
+		wantPar bool
+	}{
+		{
+			name:    "партиции обрабатываются параллельно и по порядку",
+			policy:  PoolPolicy{Workers: 4, OrderBy: OrderByPartition},
+			wantPar: true,
+		},
+		{
+			name:    "ключи одной партиции обрабатываются параллельно и по порядку",
+			policy:  PoolPolicy{Workers: 4, OrderBy: OrderByKey},
+			keyed:   true,
+			wantPar: true,
+		},
+		{
+			name:   "один воркер работает последовательно",
+			policy: PoolPolicy{Workers: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			msgs := poolMessages(2, 5, tt.keyed)
+			reader := &stubReader{messages: msgs, cancel: cancel, block: true}
+			handler := &recordingHandler{processed: map[string][]string{}}
+
+			c := NewConsumer(reader, zap.NewNop(), handler, nil, RetryPolicy{MaxAttempts: 1})
+
+			var wg sync.WaitGroup
+			wg.Add(1)
+			c.ConsumeConcurrent(ctx, &wg, tt.policy)
+			wg.Wait()
+
+			assert.Len(t, reader.committed, len(msgs))
+			assert.Equal(t, tt.wantPar, handler.maxFlight > 1)
+
+			want := map[string][]string{}
+			for _, m := range msgs {
+				group, _, _ := strings.Cut(string(m.Value), "/")
+				want[group] = append(want[group], string(m.Value))
+			}
+			assert.Equal(t, want, handler.processed)
+
+			committed := map[int64]int{}
+			for i, offset := range reader.committed {
+				committed[offset] = i
+			}
+			for i := 1; i < len(msgs); i++ {
+				prev, cur := msgs[i-1], msgs[i]
+				if prev.Partition == cur.Partition && !tt.keyed {
+					assert.Less(t, committed[prev.Offset], committed[cur.Offset])
+				}
+			}
+		})
+	}
+}
+
+func TestConsumer_ConsumeConcurrent_StopsWithoutCommittingPastFailure(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	msgs := poolMessages(2, 3, false)
+	reader := &stubReader{messages: msgs, cancel: cancel, block: true}
+	handler := &recordingHandler{
+		processed: map[string][]string{},
+		errs:      map[string]error{"p0/1": fmt.Errorf("%w: %w", apperrors.ErrTxBegin, apperrors.ErrDBConnection)},
+	}
+
+	c := NewConsumer(reader, zap.NewNop(), handler, nil, RetryPolicy{MaxAttempts: 1})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	c.ConsumeConcurrent(ctx, &wg, PoolPolicy{Workers: 2})
+	wg.Wait()
+
+	assert.NoError(t, ctx.Err())
+	for _, offset := range reader.committed {
+		assert.False(t, offset >= 1 && offset < 100, "оффсет %d партиции 0 после ошибки закоммичен", offset)
+	}
+}
+
+func TestOffsetTracker_Complete(t *testing.T) {
+	tracker := newOffsetTracker()
+	msg := func(partition int, offset int64) kafka.Message {
+		return kafka.Message{Topic: "order-service", Partition: partition, Offset: offset}
+	}
+
+	for _, m := range []kafka.Message{msg(0, 0), msg(0, 1), msg(0, 2), msg(1, 0)} {
+		tracker.track(m)
+	}
+
+	var committed [][]int64
+	commit := func(ready ...kafka.Message) {
+		var offsets []int64
+		for _, m := range ready {
+			offsets = append(offsets, m.Offset)
+		}
+		committed = append(committed, offsets)
+	}
+
+	tracker.complete(msg(0, 1), commit)
+	assert.Empty(t, committed, "нельзя коммитить, пока не обработан оффсет 0")
+
+	tracker.complete(msg(1, 0), commit)
+	tracker.complete(msg(0, 0), commit)
+	tracker.complete(msg(0, 2), commit)
+
+	assert.Equal(t, [][]int64{{0}, {0, 1}, {2}}, committed)
+}
+
+func TestOffsetTracker_CommitsOutsideLock(t *testing.T) {
+	tracker := newOffsetTracker()
+	msg := func(partition int, offset int64) kafka.Message {
+		return kafka.Message{Topic: "order-service", Partition: partition, Offset: offset}
+	}
+
+	for _, m := range []kafka.Message{msg(0, 0), msg(0, 1), msg(0, 2), msg(1, 0)} {
+		tracker.track(m)
+	}
+
+	var mu sync.Mutex
+	var committed [][]int64
+	commit := func(ready ...kafka.Message) {
+		var offsets []int64
+		for _, m := range ready {
+			offsets = append(offsets, m.Offset)
+		}
+		mu.Lock()
+		committed = append(committed, offsets)
+		mu.Unlock()
+	}
+
+	var once sync.Once
+	entered, release := make(chan struct{}), make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		tracker.complete(msg(0, 0), func(ready ...kafka.Message) {
+			once.Do(func() { close(entered) })
+			<-release
+			commit(ready...)
+		})
+	}()
+	<-entered
+
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		tracker.complete(msg(1, 0), commit)
+		tracker.complete(msg(0, 1), commit)
+		tracker.complete(msg(0, 2), commit)
+	}()
+
+	select {
+	case <-finished:
+	case <-time.After(time.Second):
+		t.Fatal("воркеры ждут коммит другого воркера")
+	}
+
+	close(release)
+	<-done
+
+	assert.Equal(t, [][]int64{{0}, {0}, {1, 2}}, committed, "партиция 1 коммитится сразу, а накопленное в партиции 0 — после текущего коммита")
+}
@@ -0,0 +1,98 @@
+package kafka
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+)
+
+const EventTypeHeader = "event-type"
+
+// Route связывает топик и тип события с обработчиком и его политикой повторов
+// и dlq. Тип берется из заголовка event-type, а без заголовка — из поля
+// event_type json конверта. Пустой Topic или EventType подходит под любое
+// значение.
+type Route struct {
+	Topic     string
+	EventType string
+	Handler   messageHandler
+	Retry     RetryPolicy
+	DLQ       *DeadLetterQueue
+}
+
+type routeKey struct {
+	topic     string
+	eventType string
+}
+
+type Router struct {
+	routes     map[routeKey]*Route
+	unroutable *Route
+}
+
+// NewRouter создает роутер. Сообщения, для которых нет маршрута, уходят в dlq
+// без повторов.
+func NewRouter(dlq *DeadLetterQueue, routes ...Route) *Router {
+	r := &Router{
+		routes:     make(map[routeKey]*Route, len(routes)),
+		unroutable: &Route{Handler: unroutableHandler{}, DLQ: dlq},
+	}
+
+	for i := range routes {
+		rt := routes[i]
+		r.routes[routeKey{topic: rt.Topic, eventType: rt.EventType}] = &rt
+	}
+
+	return r
+}
+
+func (r *Router) match(m kafka.Message) *Route {
+	eventType := eventTypeOf(m)
+	for _, key := range []routeKey{
+		{topic: m.Topic, eventType: eventType},
+		{topic: m.Topic},
+		{eventType: eventType},
+		{},
+	} {
+		if rt, ok := r.routes[key]; ok {
+			return rt
+		}
+	}
+
+	return r.unroutable
+}
+
+func eventTypeOf(m kafka.Message) string {
+	for _, h := range m.Headers {
+		if h.Key == EventTypeHeader {
+			return string(h.Value)
+		}
+	}
+
+	// Бинарные форматы несут только order.created, поэтому конверт ищется
+	// лишь в json.
+	value := bytes.TrimLeft(m.Value, " \t\r\n")
+	if len(value) == 0 || value[0] != '{' {
+		return ""
+	}
+
+	var probe struct {
+		EventType string `json:"event_type"`
+	}
+	if err := json.Unmarshal(value, &probe); err != nil {
+		return ""
+	}
+
+	return probe.EventType
+}
+
+type unroutableHandler struct{}
+
+func (unroutableHandler) HandleMessage(_ context.Context, m kafka.Message) error {
+	return fmt.Errorf("topic %s, event-type %q: %w", m.Topic, eventTypeOf(m), apperrors.ErrUnknownEventType)
+}
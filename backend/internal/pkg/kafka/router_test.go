@@ -0,0 +1,106 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+	"github.com/avraam311/order-service/backend/internal/pkg/kafka/kafkatest"
+)
+
+func eventMessage(topic, eventType string, offset int64, value string) kafka.Message {
+	m := kafka.Message{Topic: topic, Offset: offset, Value: []byte(value)}
+	if eventType != "" {
+		m.Headers = []kafka.Header{{Key: EventTypeHeader, Value: []byte(eventType)}}
+	}
+
+	return m
+}
+
+func TestRouter_Match(t *testing.T) {
+	created := &stubHandler{}
+	status := &stubHandler{}
+	payment := &stubHandler{}
+	anyPayment := &stubHandler{}
+
+	r := NewRouter(nil,
+		Route{Topic: "orders", Handler: created},
+		Route{Topic: "orders", EventType: "order.status_changed", Handler: status},
+		Route{Topic: "payments", EventType: "payment.updated", Handler: payment},
+		Route{EventType: "payment.refunded", Handler: anyPayment},
+	)
+
+	tests := []struct {
+		name string
+		msg  kafka.Message
+		want messageHandler
+	}{
+		{"точное совпадение топика и типа", eventMessage("orders", "order.status_changed", 0, ""), status},
+		{"топик без заголовка", eventMessage("orders", "", 0, ""), created},
+		{"неизвестный тип идет в обработчик топика", eventMessage("orders", "order.archived", 0, ""), created},
+		{"тип события в любом топике", eventMessage("refunds", "payment.refunded", 0, ""), anyPayment},
+		{"другой топик", eventMessage("payments", "payment.updated", 0, ""), payment},
+		{"нет маршрута", eventMessage("payments", "", 0, ""), unroutableHandler{}},
+		{"тип из конверта без заголовка", eventMessage("orders", "", 0, `{"event_type":"order.status_changed","schema_version":1,"payload":{}}`), status},
+		{"заголовок важнее конверта", eventMessage("orders", "order.archived", 0, `{"event_type":"order.status_changed"}`), created},
+		{"голый заказ без заголовка", eventMessage("orders", "", 0, `{"order_uid":"b563feb7-b2b8-4b6a-9f5d-1a6b7c7d8e9f"}`), created},
+		{"бинарное сообщение без заголовка", eventMessage("payments", "", 0, "\x00\x00\x00\x00\x01"), unroutableHandler{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.True(t, tt.want == r.match(tt.msg).Handler)
+		})
+	}
+}
+
+func TestConsumer_Routes(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	retryableErr := fmt.Errorf("%w: %w", apperrors.ErrTxBegin, apperrors.ErrDBConnection)
+	orders := &stubHandler{called: map[string]int{}}
+	payments := &stubHandler{
+		called: map[string]int{},
+		errs:   map[string][]error{"pay": {retryableErr, retryableErr, retryableErr, retryableErr}},
+	}
+
+	ordersDLQ := &kafkatest.Writer{}
+	paymentsDLQ := &kafkatest.Writer{}
+	defaultDLQ := &kafkatest.Writer{}
+
+	router := NewRouter(NewDeadLetterQueue(defaultDLQ),
+		Route{Topic: "orders", Handler: orders, Retry: RetryPolicy{MaxAttempts: 1}, DLQ: NewDeadLetterQueue(ordersDLQ)},
+		Route{Topic: "payments", EventType: "payment.updated", Handler: payments, Retry: RetryPolicy{MaxAttempts: 5}, DLQ: NewDeadLetterQueue(paymentsDLQ)},
+	)
+
+	reader := &stubReader{
+		messages: []kafka.Message{
+			eventMessage("orders", "", 0, "order"),
+			eventMessage("payments", "payment.updated", 0, "pay"),
+			eventMessage("payments", "payment.unknown", 1, "unknown"),
+		},
+		cancel: cancel,
+	}
+
+	c := NewRoutedConsumer(reader, zap.NewNop(), router)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	c.ConsumeMessage(ctx, &wg)
+	wg.Wait()
+
+	assert.Equal(t, 1, orders.called["order"])
+	assert.Equal(t, 5, payments.called["pay"], "повторы по политике маршрута payments")
+	assert.Empty(t, ordersDLQ.Messages())
+	assert.Empty(t, paymentsDLQ.Messages(), "пятая попытка успешна")
+	assert.Len(t, defaultDLQ.Messages(), 1, "сообщение без маршрута уходит в dlq по умолчанию")
+	assert.Equal(t, string(apperrors.CodeUnknownEvent), kafkatest.HeaderValue(defaultDLQ.Messages()[0], HeaderErrorClass))
+	assert.Equal(t, []int64{0, 0, 1}, reader.committed)
+}
@@ -0,0 +1,127 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+
+	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+	kafkapkg "github.com/avraam311/order-service/backend/internal/pkg/kafka"
+	"github.com/avraam311/order-service/backend/internal/pkg/kafka/codec"
+)
+
+const HeaderOutboxID = "x-outbox-id"
+
+type store interface {
+	PublishPending(ctx context.Context, limit int, publish func(ctx context.Context, events []models.OutboxEvent) error) (int, error)
+	DeletePublished(ctx context.Context, before time.Time) (int64, error)
+}
+
+type messageWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+}
+
+// Relay переносит события из outbox в kafka. Строка отмечается опубликованной
+// только после успешной записи, поэтому доставка — at-least-once: при сбое
+// между записью и коммитом событие уйдет повторно.
+type Relay struct {
+	store     store
+	writer    messageWriter
+	logger    *zap.Logger
+	interval  time.Duration
+	batchSize int
+	retention time.Duration
+	now       func() time.Time
+}
+
+func NewRelay(s store, w messageWriter, l *zap.Logger, interval time.Duration, batchSize int, retention time.Duration) *Relay {
+	return &Relay{
+		store:     s,
+		writer:    w,
+		logger:    l,
+		interval:  interval,
+		batchSize: max(batchSize, 1),
+		retention: retention,
+		now:       time.Now,
+	}
+}
+
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		r.publishPending(ctx)
+		r.cleanup(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Relay) publishPending(ctx context.Context) int {
+	total := 0
+	for ctx.Err() == nil {
+		n, err := r.store.PublishPending(ctx, r.batchSize, r.publish)
+		total += n
+		if err != nil {
+			if ctx.Err() == nil {
+				r.logger.Warn("ошибка публикации событий outbox", zap.Error(err))
+			}
+			break
+		}
+		if n < r.batchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		r.logger.Debug("события outbox опубликованы", zap.Int("events_count", total))
+	}
+
+	return total
+}
+
+func (r *Relay) publish(ctx context.Context, events []models.OutboxEvent) error {
+	msgs := make([]kafka.Message, len(events))
+	for i, e := range events {
+		msgs[i] = kafka.Message{
+			Key:   []byte(e.OrderID.String()),
+			Value: e.Payload,
+			Headers: []kafka.Header{
+				{Key: kafkapkg.EventTypeHeader, Value: []byte(e.EventType)},
+				{Key: codec.ContentTypeHeader, Value: []byte(codec.ContentTypeJSON)},
+				{Key: HeaderOutboxID, Value: []byte(strconv.FormatInt(e.ID, 10))},
+			},
+		}
+	}
+
+	if err := r.writer.WriteMessages(ctx, msgs...); err != nil {
+		return fmt.Errorf("backend/internal/pkg/outbox/relay.go: %w: %w", apperrors.ErrOutboxPublish, err)
+	}
+
+	return nil
+}
+
+func (r *Relay) cleanup(ctx context.Context) {
+	if r.retention <= 0 || ctx.Err() != nil {
+		return
+	}
+
+	n, err := r.store.DeletePublished(ctx, r.now().Add(-r.retention))
+	if err != nil {
+		r.logger.Warn("ошибка очистки outbox", zap.Error(err))
+		return
+	}
+	if n > 0 {
+		r.logger.Debug("опубликованные события outbox удалены", zap.Int64("events_count", n))
+	}
+}
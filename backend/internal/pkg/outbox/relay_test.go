@@ -0,0 +1,233 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+	kafkapkg "github.com/avraam311/order-service/backend/internal/pkg/kafka"
+	"github.com/avraam311/order-service/backend/internal/pkg/kafka/codec"
+	"github.com/avraam311/order-service/backend/internal/pkg/kafka/kafkatest"
+)
+
+type fakeRow struct {
+	event       models.OutboxEvent
+	published   bool
+	publishedAt time.Time
+}
+
+type fakeStore struct {
+	mu         sync.Mutex
+	rows       []fakeRow
+	now        time.Time
+	publishErr error
+}
+
+func (s *fakeStore) add(orderID uuid.UUID, eventType string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rows = append(s.rows, fakeRow{event: models.OutboxEvent{
+		ID:        int64(len(s.rows) + 1),
+		OrderID:   orderID,
+		EventType: eventType,
+		Payload:   []byte(`{"event_type":"` + eventType + `"}`),
+	}})
+}
+
+func (s *fakeStore) PublishPending(ctx context.Context, limit int, publish func(context.Context, []models.OutboxEvent) error) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var idx []int
+	var events []models.OutboxEvent
+	for i, r := range s.rows {
+		if len(events) == limit {
+			break
+		}
+		if !r.published {
+			idx = append(idx, i)
+			events = append(events, r.event)
+		}
+	}
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	if err := publish(ctx, events); err != nil {
+		return 0, err
+	}
+
+	for _, i := range idx {
+		s.rows[i].published = true
+		s.rows[i].publishedAt = s.now
+	}
+
+	return len(events), nil
+}
+
+func (s *fakeStore) DeletePublished(_ context.Context, before time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kept := s.rows[:0]
+	var n int64
+	for _, r := range s.rows {
+		if r.published && r.publishedAt.Before(before) {
+			n++
+			continue
+		}
+		kept = append(kept, r)
+	}
+	s.rows = kept
+
+	return n, nil
+}
+
+func (s *fakeStore) pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := 0
+	for _, r := range s.rows {
+		if !r.published {
+			n++
+		}
+	}
+
+	return n
+}
+
+func TestRelay_Publish(t *testing.T) {
+	orderID := uuid.New()
+
+	tests := []struct {
+		name      string
+		writerErr error
+		wantErr   error
+	}{
+		{
+			name: "события отправлены с ключом и заголовками",
+		},
+		{
+			name:      "ошибка записи",
+			writerErr: errors.New("broker down"),
+			wantErr:   apperrors.ErrOutboxPublish,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &kafkatest.Writer{Err: tt.writerErr}
+			r := NewRelay(&fakeStore{}, w, zaptest.NewLogger(t), time.Second, 10, time.Hour)
+
+			events := []models.OutboxEvent{
+				{ID: 7, OrderID: orderID, EventType: "order.created", Payload: []byte(`{"event_type":"order.created"}`)},
+				{ID: 9, OrderID: orderID, EventType: "payment.updated", Payload: []byte(`{"event_type":"payment.updated"}`)},
+			}
+
+			err := r.publish(context.Background(), events)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, w.Messages())
+				return
+			}
+
+			require.NoError(t, err)
+			got := w.Messages()
+			require.Len(t, got, len(events))
+			for i, m := range got {
+				assert.Equal(t, []byte(orderID.String()), m.Key)
+				assert.Equal(t, events[i].Payload, m.Value)
+				assert.Equal(t, events[i].EventType, kafkatest.HeaderValue(m, kafkapkg.EventTypeHeader))
+				assert.Equal(t, codec.ContentTypeJSON, kafkatest.HeaderValue(m, codec.ContentTypeHeader))
+				assert.Equal(t, strconv.FormatInt(events[i].ID, 10), kafkatest.HeaderValue(m, HeaderOutboxID))
+			}
+		})
+	}
+}
+
+func TestRelay_PublishesAllPendingBatches(t *testing.T) {
+	s := &fakeStore{}
+	for range 5 {
+		s.add(uuid.New(), "order.created")
+	}
+
+	w := &kafkatest.Writer{}
+	r := NewRelay(s, w, zaptest.NewLogger(t), time.Second, 2, time.Hour)
+
+	assert.Equal(t, 5, r.publishPending(context.Background()))
+	assert.Equal(t, 0, s.pending())
+	assert.Len(t, w.Messages(), 5)
+}
+
+func TestRelay_RetriesAfterWriteError(t *testing.T) {
+	s := &fakeStore{}
+	s.add(uuid.New(), "order.created")
+
+	w := &kafkatest.Writer{Err: errors.New("broker down")}
+	r := NewRelay(s, w, zaptest.NewLogger(t), time.Second, 10, time.Hour)
+
+	assert.Equal(t, 0, r.publishPending(context.Background()))
+	assert.Equal(t, 1, s.pending())
+
+	w.SetErr(nil)
+	assert.Equal(t, 1, r.publishPending(context.Background()))
+	assert.Equal(t, 0, s.pending())
+	require.Len(t, w.Messages(), 1)
+	assert.Equal(t, "1", kafkatest.HeaderValue(w.Messages()[0], HeaderOutboxID))
+}
+
+func TestRelay_Cleanup(t *testing.T) {
+	now := time.Date(2025, 8, 20, 12, 0, 0, 0, time.UTC)
+
+	s := &fakeStore{now: now.Add(-2 * time.Hour)}
+	s.add(uuid.New(), "order.created")
+	s.add(uuid.New(), "order.created")
+
+	r := NewRelay(s, &kafkatest.Writer{}, zaptest.NewLogger(t), time.Second, 10, time.Hour)
+	r.now = func() time.Time { return now }
+
+	require.Equal(t, 2, r.publishPending(context.Background()))
+
+	s.now = now
+	s.add(uuid.New(), "order.created")
+	require.Equal(t, 1, r.publishPending(context.Background()))
+
+	r.cleanup(context.Background())
+	require.Len(t, s.rows, 1)
+	assert.Equal(t, int64(3), s.rows[0].event.ID)
+}
+
+func TestRelay_Run(t *testing.T) {
+	s := &fakeStore{}
+	s.add(uuid.New(), "order.created")
+
+	w := &kafkatest.Writer{}
+	r := NewRelay(s, w, zaptest.NewLogger(t), 10*time.Millisecond, 10, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		r.Run(ctx)
+		close(done)
+	}()
+
+	s.add(uuid.New(), "order.status_changed")
+	assert.Eventually(t, func() bool { return s.pending() == 0 }, time.Second, 5*time.Millisecond)
+
+	cancel()
+	<-done
+
+	assert.Len(t, w.Messages(), 2)
+}
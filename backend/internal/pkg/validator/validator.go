@@ -1,17 +1,62 @@
 package validator
 
-import "github.com/go-playground/validator/v10"
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
 
 type GoValidator struct {
 	validate *validator.Validate
 }
 
+type FieldError struct {
+	Field string `json:"field"`
+	Rule  string `json:"rule"`
+	Param string `json:"param,omitempty"`
+}
+
 func New() *GoValidator {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(fld reflect.StructField) string {
+		name, _, _ := strings.Cut(fld.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+
+		return name
+	})
+
 	return &GoValidator{
-		validate: validator.New(),
+		validate: v,
 	}
 }
 
 func (v *GoValidator) Validate(i interface{}) error {
 	return v.validate.Struct(i)
 }
+
+func FieldErrors(err error) []FieldError {
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return nil
+	}
+
+	fields := make([]FieldError, 0, len(validationErrs))
+	for _, fe := range validationErrs {
+		field := fe.Namespace()
+		if _, rest, found := strings.Cut(field, "."); found {
+			field = rest
+		}
+
+		fields = append(fields, FieldError{
+			Field: field,
+			Rule:  fe.Tag(),
+			Param: fe.Param(),
+		})
+	}
+
+	return fields
+}
@@ -0,0 +1,24 @@
+package order
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/avraam311/order-service/backend/internal/models"
+)
+
+func contentHash(order *models.Order) (string, error) {
+	o := *order
+	o.DateCreated = time.Time{}
+	o.Status = ""
+
+	b, err := json.Marshal(o)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
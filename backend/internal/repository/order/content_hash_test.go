@@ -0,0 +1,41 @@
+package order
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avraam311/order-service/backend/internal/models"
+)
+
+func TestContentHash(t *testing.T) {
+	order := &models.Order{
+		OrderID:     uuid.New(),
+		TrackNumber: "WBILMTESTTRACK",
+		Items:       []models.Item{{ChrtID: 1, Price: 100}, {ChrtID: 2, Price: 200}},
+	}
+
+	base, err := contentHash(order)
+	require.NoError(t, err)
+
+	redelivered := *order
+	redelivered.DateCreated = time.Now()
+	got, err := contentHash(&redelivered)
+	require.NoError(t, err)
+	assert.Equal(t, base, got, "date_created не должен влиять на хэш")
+
+	shipped := *order
+	shipped.Status = models.StatusShipped
+	got, err = contentHash(&shipped)
+	require.NoError(t, err)
+	assert.Equal(t, base, got, "статус не должен влиять на хэш")
+
+	changed := *order
+	changed.Items = []models.Item{{ChrtID: 2, Price: 200}, {ChrtID: 1, Price: 100}}
+	got, err = contentHash(&changed)
+	require.NoError(t, err)
+	assert.NotEqual(t, base, got, "порядок items влияет на хэш")
+}
@@ -0,0 +1,44 @@
+package order
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+)
+
+type cursor struct {
+	SortBy      models.OrderSortField `json:"s"`
+	Ascending   bool                  `json:"a,omitempty"`
+	DateCreated time.Time             `json:"d,omitempty"`
+	Amount      int                   `json:"m,omitempty"`
+	OrderID     uuid.UUID             `json:"id"`
+}
+
+func encodeCursor(c cursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeCursor(s string, f models.OrderFilter) (*cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("backend/internal/repository/order/cursor.go, декодирование курсора: %w", apperrors.ErrInvalidCursor)
+	}
+
+	var c cursor
+	if err = json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("backend/internal/repository/order/cursor.go, разбор курсора: %w", apperrors.ErrInvalidCursor)
+	}
+
+	if c.SortBy != f.SortBy || c.Ascending != f.Ascending || c.OrderID == uuid.Nil {
+		return nil, fmt.Errorf("backend/internal/repository/order/cursor.go, курсор от другой сортировки: %w", apperrors.ErrInvalidCursor)
+	}
+
+	return &c, nil
+}
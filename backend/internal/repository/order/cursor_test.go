@@ -0,0 +1,72 @@
+package order
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+)
+
+func TestCursor_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		c    cursor
+	}{
+		{
+			name: "по дате по убыванию",
+			c: cursor{
+				SortBy:      models.SortByDateCreated,
+				DateCreated: time.Date(2025, 8, 5, 10, 0, 0, 123456000, time.UTC),
+				OrderID:     uuid.New(),
+			},
+		},
+		{
+			name: "по сумме по возрастанию",
+			c: cursor{
+				SortBy:    models.SortByAmount,
+				Ascending: true,
+				Amount:    1817,
+				OrderID:   uuid.New(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeCursor(encodeCursor(tt.c), models.OrderFilter{SortBy: tt.c.SortBy, Ascending: tt.c.Ascending})
+			require.NoError(t, err)
+			assert.Equal(t, tt.c, *got)
+		})
+	}
+}
+
+func TestDecodeCursor_Invalid(t *testing.T) {
+	f := models.OrderFilter{SortBy: models.SortByDateCreated}
+	valid := encodeCursor(cursor{SortBy: models.SortByDateCreated, OrderID: uuid.New()})
+
+	tests := []struct {
+		name   string
+		cursor string
+		filter models.OrderFilter
+	}{
+		{name: "не base64", cursor: "не курсор", filter: f},
+		{name: "испорченный json", cursor: valid[:len(valid)-4], filter: f},
+		{name: "подмененный json", cursor: base64.RawURLEncoding.EncodeToString([]byte(`{"s":"date_created","id":"not-a-uuid"}`)), filter: f},
+		{name: "без order_uid", cursor: encodeCursor(cursor{SortBy: models.SortByDateCreated}), filter: f},
+		{name: "другое поле сортировки", cursor: valid, filter: models.OrderFilter{SortBy: models.SortByAmount}},
+		{name: "другое направление", cursor: valid, filter: models.OrderFilter{SortBy: models.SortByDateCreated, Ascending: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := decodeCursor(tt.cursor, tt.filter)
+			assert.ErrorIs(t, err, apperrors.ErrInvalidCursor)
+		})
+	}
+}
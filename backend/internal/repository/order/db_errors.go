@@ -0,0 +1,57 @@
+package order
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+)
+
+func wrapDBError(sentinel error, err error) error {
+	if isConnectionError(err) {
+		return fmt.Errorf("backend/internal/repository/order_repo.go: %w: %w", sentinel, apperrors.ErrDBConnection)
+	}
+
+	return fmt.Errorf("backend/internal/repository/order_repo.go: %w", sentinel)
+}
+
+func isConnectionError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if pgconn.SafeToRetry(err) || pgconn.Timeout(err) {
+		return true
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		// 08 - connection exception, 57P01-57P03 - остановка сервера,
+		// 53300 - слишком много соединений, 40001/40P01 - конфликт сериализации и дедлок
+		switch {
+		case strings.HasPrefix(pgErr.Code, "08"),
+			pgErr.Code == "57P01", pgErr.Code == "57P02", pgErr.Code == "57P03",
+			pgErr.Code == "53300", pgErr.Code == "40001", pgErr.Code == "40P01":
+			return true
+		}
+
+		return false
+	}
+
+	var connectErr *pgconn.ConnectError
+	if errors.As(err, &connectErr) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, net.ErrClosed)
+}
@@ -0,0 +1,60 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/models/modeltest"
+)
+
+// testRepository подключается к бд из TEST_DATABASE_URL с примененными миграциями.
+func testRepository(tb testing.TB) *Repository {
+	tb.Helper()
+
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		tb.Skip("TEST_DATABASE_URL не задан")
+	}
+
+	pool, err := pgxpool.New(context.Background(), url)
+	if err != nil {
+		tb.Fatalf("подключение к бд: %v", err)
+	}
+	tb.Cleanup(pool.Close)
+
+	return New(pool)
+}
+
+func testOrderWithItems(tb testing.TB, items int) *models.Order {
+	o := modeltest.Order(tb)
+	o.OrderID = uuid.New()
+	o.Payment.Transaction = o.OrderID.String()
+	o.CustomerId = "bench"
+
+	item := o.Items[0]
+	o.Items = make([]models.Item, items)
+	for i := range o.Items {
+		o.Items[i] = item
+		o.Items[i].ChrtID = item.ChrtID + i
+		o.Items[i].RID = fmt.Sprintf("rid-%d", i)
+	}
+
+	return o
+}
+
+func deleteTestOrder(tb testing.TB, r *Repository, orderID uuid.UUID) {
+	tb.Helper()
+
+	if _, err := r.db.Exec(context.Background(), `DELETE FROM orders WHERE order_uid = $1;`, orderID); err != nil {
+		tb.Errorf("удаление тестового заказа: %v", err)
+	}
+	if _, err := r.db.Exec(context.Background(), `DELETE FROM outbox WHERE aggregate_id = $1;`, orderID); err != nil {
+		tb.Errorf("удаление событий outbox: %v", err)
+	}
+}
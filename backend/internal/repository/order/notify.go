@@ -0,0 +1,118 @@
+package order
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+const OrderChangedChannel = "order_changed"
+
+type ChangeEvent struct {
+	OrderID   uuid.UUID `json:"order_uid"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+const notifyQuery = `SELECT pg_notify($1, $2);`
+
+func changePayload(orderID uuid.UUID, changedAt time.Time) (string, error) {
+	payload, err := json.Marshal(ChangeEvent{OrderID: orderID, ChangedAt: changedAt.UTC()})
+	return string(payload), err
+}
+
+func notifyChanged(ctx context.Context, tx pgx.Tx, orderID uuid.UUID, changedAt time.Time) error {
+	payload, err := changePayload(orderID, changedAt)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, notifyQuery, OrderChangedChannel, payload)
+	return err
+}
+
+func parseChangeEvent(payload string) (ChangeEvent, error) {
+	var e ChangeEvent
+	if err := json.Unmarshal([]byte(payload), &e); err != nil {
+		return ChangeEvent{}, err
+	}
+
+	if e.OrderID == uuid.Nil {
+		return ChangeEvent{}, errors.New("пустой order_uid")
+	}
+
+	return e, nil
+}
+
+type Listener struct {
+	db             *pgxpool.Pool
+	logger         *zap.Logger
+	reconnectDelay time.Duration
+}
+
+func NewListener(db *pgxpool.Pool, l *zap.Logger) *Listener {
+	return &Listener{
+		db:             db,
+		logger:         l,
+		reconnectDelay: time.Second,
+	}
+}
+
+// Listen блокируется до отмены ctx. После переподключения вызывается onReconnect,
+// так как уведомления, пришедшие пока соединения не было, потеряны.
+func (l *Listener) Listen(ctx context.Context, onChange func(ChangeEvent), onReconnect func()) {
+	connected := false
+	for ctx.Err() == nil {
+		err := l.listen(ctx, onChange, func() {
+			if connected && onReconnect != nil {
+				onReconnect()
+			}
+			connected = true
+		})
+		if ctx.Err() != nil {
+			return
+		}
+
+		l.logger.Warn("соединение LISTEN потеряно, переподключение", zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(l.reconnectDelay):
+		}
+	}
+}
+
+func (l *Listener) listen(ctx context.Context, onChange func(ChangeEvent), onConnected func()) error {
+	conn, err := l.db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err = conn.Exec(ctx, "LISTEN "+OrderChangedChannel); err != nil {
+		return err
+	}
+	onConnected()
+
+	for {
+		n, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			conn.Conn().Close(context.Background())
+			return err
+		}
+
+		e, err := parseChangeEvent(n.Payload)
+		if err != nil {
+			l.logger.Warn("неправильное уведомление об изменении заказа", zap.String("payload", n.Payload), zap.Error(err))
+			continue
+		}
+
+		onChange(e)
+	}
+}
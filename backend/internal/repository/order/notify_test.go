@@ -0,0 +1,52 @@
+package order
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseChangeEvent(t *testing.T) {
+	orderID := uuid.New()
+	changedAt := time.Date(2025, 8, 20, 12, 0, 0, 123000, time.UTC)
+
+	tests := []struct {
+		name    string
+		payload string
+		want    ChangeEvent
+		wantErr bool
+	}{
+		{
+			name:    "корректное уведомление",
+			payload: `{"order_uid":"` + orderID.String() + `","changed_at":"2025-08-20T12:00:00.000123Z"}`,
+			want:    ChangeEvent{OrderID: orderID, ChangedAt: changedAt},
+		},
+		{
+			name:    "неправильный json",
+			payload: `order_uid`,
+			wantErr: true,
+		},
+		{
+			name:    "пустой order_uid",
+			payload: `{"changed_at":"2025-08-20T12:00:00Z"}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseChangeEvent(tt.payload)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.OrderID, got.OrderID)
+			assert.True(t, tt.want.ChangedAt.Equal(got.ChangedAt))
+		})
+	}
+}
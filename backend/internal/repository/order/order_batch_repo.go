@@ -0,0 +1,106 @@
+package order
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+)
+
+func (r *Repository) GetOrdersByIDs(ctx context.Context, orderIDs []uuid.UUID) ([]models.Order, error) {
+	if len(orderIDs) == 0 {
+		return nil, nil
+	}
+
+	query := orderSelect + `
+	WHERE o.order_uid = ANY($1);
+	`
+
+	rows, err := r.db.Query(ctx, query, orderIDs)
+	if err != nil {
+		return nil, wrapDBError(apperrors.ErrGetOrdersByIDs, err)
+	}
+	defer rows.Close()
+
+	byID := make(map[uuid.UUID]models.Order, len(orderIDs))
+	for rows.Next() {
+		var o models.Order
+		if err = scanOrder(rows, &o); err != nil {
+			return nil, fmt.Errorf("backend/internal/repository/order_batch_repo.go, сканирование строки: %w", apperrors.ErrScanRow)
+		}
+
+		byID[o.OrderID] = o
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, wrapDBError(apperrors.ErrGetOrdersByIDs, err)
+	}
+
+	orders := make([]models.Order, 0, len(byID))
+	for _, id := range orderIDs {
+		if o, ok := byID[id]; ok {
+			orders = append(orders, o)
+			delete(byID, id)
+		}
+	}
+
+	if err = r.loadItems(ctx, orders); err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+func (r *Repository) loadItems(ctx context.Context, orders []models.Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(orders))
+	for i := range orders {
+		ids[i] = orders[i].OrderID
+	}
+
+	query := `
+	SELECT order_id, chrt_id, track_number, price, rid, name, sale, size, total_price, nm_id, brand, status
+	FROM items
+	WHERE order_id = ANY($1)
+	ORDER BY order_id, position;
+	`
+
+	rows, err := r.db.Query(ctx, query, ids)
+	if err != nil {
+		return wrapDBError(apperrors.ErrGetItemsByOrderId, err)
+	}
+	defer rows.Close()
+
+	items := make(map[uuid.UUID][]models.Item, len(orders))
+	for rows.Next() {
+		var (
+			orderID uuid.UUID
+			item    models.Item
+		)
+		err = rows.Scan(
+			&orderID, &item.ChrtID, &item.TrackNumber, &item.Price, &item.RID, &item.Name, &item.Sale,
+			&item.Size, &item.TotalPrice, &item.NmID, &item.Brand, &item.Status,
+		)
+		if err != nil {
+			return fmt.Errorf("backend/internal/repository/order_batch_repo.go, сканирование строки item: %w", apperrors.ErrItemScanFailed)
+		}
+
+		items[orderID] = append(items[orderID], item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return wrapDBError(apperrors.ErrGetItemsByOrderId, err)
+	}
+
+	for i := range orders {
+		orders[i].Items = items[orders[i].OrderID]
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package order
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+)
+
+// SaveOrders сохраняет заказы в одной транзакции, каждый в своем savepoint:
+// ошибка одного заказа откатывает только его. Ошибки по заказам возвращаются
+// в том же порядке; общая ошибка означает, что не сохранен ни один заказ.
+func (r *Repository) SaveOrders(ctx context.Context, orders []*models.Order) (results []error, err error) {
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, wrapDBError(apperrors.ErrTxBegin, err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+			return
+		}
+
+		if commitErr := tx.Commit(ctx); commitErr != nil {
+			results = nil
+			err = wrapDBError(apperrors.ErrTxCommit, commitErr)
+		}
+	}()
+
+	results = make([]error, len(orders))
+	for i, order := range orders {
+		sp, err := tx.Begin(ctx)
+		if err != nil {
+			return nil, wrapDBError(apperrors.ErrTxBegin, err)
+		}
+
+		_, saveErr := saveOrder(ctx, sp, order)
+		if saveErr == nil {
+			if err = sp.Commit(ctx); err != nil {
+				return nil, wrapDBError(apperrors.ErrTxCommit, err)
+			}
+			continue
+		}
+
+		if errors.Is(saveErr, apperrors.ErrDBConnection) {
+			return nil, saveErr
+		}
+
+		if err = sp.Rollback(ctx); err != nil {
+			return nil, wrapDBError(apperrors.ErrTxRollback, err)
+		}
+		results[i] = saveErr
+	}
+
+	return results, nil
+}
@@ -0,0 +1,42 @@
+package order
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+)
+
+func TestRepository_SaveOrders(t *testing.T) {
+	r := testRepository(t)
+	ctx := context.Background()
+
+	first, broken, last := testOrderWithItems(t, 2), testOrderWithItems(t, 1), testOrderWithItems(t, 3)
+	broken.Delivery.Phone = strings.Repeat("9", 32)
+
+	orders := []*models.Order{first, broken, last}
+	for _, o := range orders {
+		t.Cleanup(func() { deleteTestOrder(t, r, o.OrderID) })
+	}
+
+	results, err := r.SaveOrders(ctx, orders)
+	require.NoError(t, err)
+	require.Len(t, results, len(orders))
+	assert.NoError(t, results[0])
+	assert.ErrorIs(t, results[1], apperrors.ErrInsertDelivery)
+	assert.NoError(t, results[2])
+
+	for _, o := range []*models.Order{first, last} {
+		got, err := r.GetFullOrder(ctx, o.OrderID)
+		require.NoError(t, err, "соседние заказы сохранены")
+		assert.Equal(t, o.Items, got.Items)
+	}
+
+	_, err = r.GetFullOrder(ctx, broken.OrderID)
+	assert.ErrorIs(t, err, apperrors.ErrOrderNotFound, "откат savepoint не оставил частично сохраненный заказ")
+}
@@ -0,0 +1,52 @@
+package order
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+)
+
+const fullOrderSelect = `
+	SELECT` + orderColumns + `,
+		COALESCE((
+			SELECT json_agg(json_build_object(
+				'chrt_id', i.chrt_id, 'track_number', i.track_number, 'price', i.price, 'rid', i.rid,
+				'name', i.name, 'sale', i.sale, 'size', i.size, 'total_price', i.total_price,
+				'nm_id', i.nm_id, 'brand', i.brand, 'status', i.status
+			) ORDER BY i.position)
+			FROM items i
+			WHERE i.order_id = o.order_uid
+		), '[]')` + orderFrom
+
+// GetFullOrder читает заказ вместе с items одним запросом, поэтому все части
+// заказа видны из одного снимка данных.
+func (r *Repository) GetFullOrder(ctx context.Context, orderID uuid.UUID) (*models.Order, error) {
+	query := fullOrderSelect + `
+	WHERE o.order_uid = $1;
+	`
+
+	var (
+		o     models.Order
+		items []byte
+	)
+	err := r.db.QueryRow(ctx, query, orderID).Scan(append(orderDest(&o), &items)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("backend/internal/repository/order_full_repo.go, получение заказа по id: %w", apperrors.ErrOrderNotFound)
+	}
+	if err != nil {
+		return nil, wrapDBError(apperrors.ErrScanRow, err)
+	}
+
+	if err = json.Unmarshal(items, &o.Items); err != nil {
+		return nil, fmt.Errorf("backend/internal/repository/order_full_repo.go, разбор items: %w", apperrors.ErrItemScanFailed)
+	}
+
+	return &o, nil
+}
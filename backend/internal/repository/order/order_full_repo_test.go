@@ -0,0 +1,66 @@
+package order
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+)
+
+func TestRepository_GetFullOrder(t *testing.T) {
+	r := testRepository(t)
+	ctx := context.Background()
+
+	order := testOrderWithItems(t, 3)
+	_, err := r.SaveOrder(ctx, order)
+	require.NoError(t, err)
+	t.Cleanup(func() { deleteTestOrder(t, r, order.OrderID) })
+
+	got, err := r.GetFullOrder(ctx, order.OrderID)
+	require.NoError(t, err)
+
+	want, err := r.GetOrderById(ctx, order.OrderID)
+	require.NoError(t, err)
+	want.Items, err = r.GetItemsByOrderID(ctx, order.OrderID)
+	require.NoError(t, err)
+
+	assert.Equal(t, want, got)
+	assert.Equal(t, order.Items, got.Items)
+
+	_, err = r.GetFullOrder(ctx, uuid.New())
+	assert.ErrorIs(t, err, apperrors.ErrOrderNotFound)
+}
+
+func BenchmarkRepository_GetOrder(b *testing.B) {
+	r := testRepository(b)
+	ctx := context.Background()
+
+	order := testOrderWithItems(b, 10)
+	_, err := r.SaveOrder(ctx, order)
+	require.NoError(b, err)
+	b.Cleanup(func() { deleteTestOrder(b, r, order.OrderID) })
+
+	b.Run("два запроса", func(b *testing.B) {
+		for b.Loop() {
+			o, err := r.GetOrderById(ctx, order.OrderID)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if o.Items, err = r.GetItemsByOrderID(ctx, order.OrderID); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("один запрос", func(b *testing.B) {
+		for b.Loop() {
+			if _, err := r.GetFullOrder(ctx, order.OrderID); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
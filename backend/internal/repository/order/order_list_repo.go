@@ -0,0 +1,104 @@
+package order
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+)
+
+func (r *Repository) ListOrders(ctx context.Context, f models.OrderFilter) (*models.OrderPage, error) {
+	query, args, err := listQuery(f)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("backend/internal/repository/order_list_repo.go, получение списка заказов: %w", apperrors.ErrListOrders)
+	}
+	defer rows.Close()
+
+	orders := make([]models.Order, 0, f.Limit+1)
+	for rows.Next() {
+		var o models.Order
+		if err = scanOrder(rows, &o); err != nil {
+			return nil, fmt.Errorf("backend/internal/repository/order_list_repo.go, сканирование строки: %w", apperrors.ErrScanRow)
+		}
+
+		orders = append(orders, o)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("backend/internal/repository/order_list_repo.go, получение списка заказов: %w", apperrors.ErrListOrders)
+	}
+	rows.Close()
+
+	page := &models.OrderPage{Orders: orders}
+	if len(orders) > f.Limit {
+		page.Orders = orders[:f.Limit]
+		last := page.Orders[len(page.Orders)-1]
+		page.NextCursor = encodeCursor(cursor{
+			SortBy:      f.SortBy,
+			Ascending:   f.Ascending,
+			DateCreated: last.DateCreated,
+			Amount:      last.Payment.Amount,
+			OrderID:     last.OrderID,
+		})
+	}
+
+	if err = r.loadItems(ctx, page.Orders); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// listQuery строит запрос страницы: фильтры, keyset условие по курсору и на
+// одну строку больше лимита, чтобы понять, есть ли следующая страница.
+func listQuery(f models.OrderFilter) (string, []any, error) {
+	var b queryBuilder
+
+	b.whereEq("o.customer_id", f.CustomerID)
+	b.whereEq("o.track_number", f.TrackNumber)
+	b.whereEq("o.delivery_service", f.DeliveryService)
+	b.whereEq("o.locale", f.Locale)
+	b.whereEq("p.provider", f.Provider)
+	b.whereEq("p.currency", f.Currency)
+	if f.CreatedFrom != nil {
+		b.where("o.date_created >= %s", *f.CreatedFrom)
+	}
+	if f.CreatedTo != nil {
+		b.where("o.date_created < %s", *f.CreatedTo)
+	}
+
+	sortColumn := "o.date_created"
+	if f.SortBy == models.SortByAmount {
+		sortColumn = "p.amount"
+	}
+
+	direction, cmp := "DESC", "<"
+	if f.Ascending {
+		direction, cmp = "ASC", ">"
+	}
+
+	if f.Cursor != "" {
+		c, err := decodeCursor(f.Cursor, f)
+		if err != nil {
+			return "", nil, err
+		}
+
+		var sortValue any = c.DateCreated
+		if f.SortBy == models.SortByAmount {
+			sortValue = c.Amount
+		}
+		b.where("("+sortColumn+", o.order_uid) "+cmp+" (%s, %s)", sortValue, c.OrderID)
+	}
+
+	query := orderSelect + b.whereClause() + fmt.Sprintf(`
+	ORDER BY %s %s, o.order_uid %s
+	LIMIT %s`, sortColumn, direction, direction, b.arg(f.Limit+1))
+
+	return query, b.args, nil
+}
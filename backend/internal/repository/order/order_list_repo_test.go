@@ -0,0 +1,169 @@
+package order
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+)
+
+func TestListQuery(t *testing.T) {
+	from := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	orderID := uuid.New()
+
+	tests := []struct {
+		name      string
+		filter    models.OrderFilter
+		wantWhere string
+		wantOrder string
+		wantArgs  []any
+	}{
+		{
+			name:      "без фильтров",
+			filter:    models.OrderFilter{Limit: 10},
+			wantOrder: "ORDER BY o.date_created DESC, o.order_uid DESC\n\tLIMIT $1",
+			wantArgs:  []any{11},
+		},
+		{
+			name:      "фильтры",
+			filter:    models.OrderFilter{CustomerID: "test", Currency: "USD", CreatedFrom: &from, Limit: 5},
+			wantWhere: "WHERE o.customer_id = $1\n\t  AND p.currency = $2\n\t  AND o.date_created >= $3",
+			wantOrder: "ORDER BY o.date_created DESC, o.order_uid DESC\n\tLIMIT $4",
+			wantArgs:  []any{"test", "USD", from, 6},
+		},
+		{
+			name: "курсор по сумме по возрастанию",
+			filter: models.OrderFilter{
+				Provider:  "wbpay",
+				SortBy:    models.SortByAmount,
+				Ascending: true,
+				Cursor:    encodeCursor(cursor{SortBy: models.SortByAmount, Ascending: true, Amount: 1817, OrderID: orderID}),
+				Limit:     2,
+			},
+			wantWhere: "WHERE p.provider = $1\n\t  AND (p.amount, o.order_uid) > ($2, $3)",
+			wantOrder: "ORDER BY p.amount ASC, o.order_uid ASC\n\tLIMIT $4",
+			wantArgs:  []any{"wbpay", 1817, orderID, 3},
+		},
+		{
+			name: "курсор по дате",
+			filter: models.OrderFilter{
+				SortBy: models.SortByDateCreated,
+				Cursor: encodeCursor(cursor{SortBy: models.SortByDateCreated, DateCreated: from, OrderID: orderID}),
+				Limit:  2,
+			},
+			wantWhere: "WHERE (o.date_created, o.order_uid) < ($1, $2)",
+			wantOrder: "ORDER BY o.date_created DESC, o.order_uid DESC\n\tLIMIT $3",
+			wantArgs:  []any{from, orderID, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, err := listQuery(tt.filter)
+			require.NoError(t, err)
+
+			assert.True(t, strings.HasPrefix(query, orderSelect))
+			if tt.wantWhere == "" {
+				assert.NotContains(t, query, "WHERE")
+			} else {
+				assert.Contains(t, query, tt.wantWhere)
+			}
+			assert.True(t, strings.HasSuffix(query, tt.wantOrder), query)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestListQuery_InvalidCursor(t *testing.T) {
+	_, _, err := listQuery(models.OrderFilter{
+		SortBy: models.SortByAmount,
+		Cursor: encodeCursor(cursor{SortBy: models.SortByDateCreated, OrderID: uuid.New()}),
+		Limit:  10,
+	})
+	assert.ErrorIs(t, err, apperrors.ErrInvalidCursor)
+}
+
+func TestRepository_ListOrders(t *testing.T) {
+	r := testRepository(t)
+	ctx := context.Background()
+
+	customerID := uuid.NewString()
+	amounts := []int{300, 100, 500, 100, 200}
+	saved := make(map[uuid.UUID]int, len(amounts))
+	for _, amount := range amounts {
+		o := testOrderWithItems(t, 1)
+		o.CustomerId = customerID
+		o.Payment.Amount = amount
+
+		_, err := r.SaveOrder(ctx, o)
+		require.NoError(t, err)
+		t.Cleanup(func() { deleteTestOrder(t, r, o.OrderID) })
+		saved[o.OrderID] = amount
+	}
+
+	tests := []struct {
+		name   string
+		filter models.OrderFilter
+		less   func(a, b models.Order) bool
+	}{
+		{
+			name:   "по дате по убыванию",
+			filter: models.OrderFilter{SortBy: models.SortByDateCreated},
+			less: func(a, b models.Order) bool {
+				if !a.DateCreated.Equal(b.DateCreated) {
+					return a.DateCreated.After(b.DateCreated)
+				}
+				return a.OrderID.String() > b.OrderID.String()
+			},
+		},
+		{
+			name:   "по сумме по возрастанию",
+			filter: models.OrderFilter{SortBy: models.SortByAmount, Ascending: true},
+			less: func(a, b models.Order) bool {
+				if a.Payment.Amount != b.Payment.Amount {
+					return a.Payment.Amount < b.Payment.Amount
+				}
+				return a.OrderID.String() < b.OrderID.String()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := tt.filter
+			f.CustomerID = customerID
+			f.Limit = 2
+
+			var all []models.Order
+			for pages := 0; ; pages++ {
+				require.Less(t, pages, len(amounts), "курсор должен продвигаться")
+
+				page, err := r.ListOrders(ctx, f)
+				require.NoError(t, err)
+				assert.LessOrEqual(t, len(page.Orders), f.Limit)
+				all = append(all, page.Orders...)
+
+				if page.NextCursor == "" {
+					break
+				}
+				f.Cursor = page.NextCursor
+			}
+
+			require.Len(t, all, len(amounts), "каждый заказ ровно на одной странице")
+			for i, o := range all {
+				assert.Equal(t, saved[o.OrderID], o.Payment.Amount)
+				assert.NotEmpty(t, o.Items, "товары загружены")
+				if i > 0 {
+					assert.True(t, tt.less(all[i-1], o), "порядок сортировки сохраняется между страницами")
+				}
+			}
+		})
+	}
+}
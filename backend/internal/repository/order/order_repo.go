@@ -4,26 +4,34 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+	"github.com/avraam311/order-service/backend/internal/pkg/kafka/envelope"
 )
 
-var (
-	ErrTxBegin           = errors.New("ошибка при начале транзакции")
-	ErrTxCommit          = errors.New("ошибка при применении транзакции")
-	ErrInsertOrder       = errors.New("ошибка при добавлении orders")
-	ErrInsertDelivery    = errors.New("ошибка при добавлении delivery")
-	ErrInsertPayment     = errors.New("ошибка при добавлении payment")
-	ErrInsertItem        = errors.New("ошибка при добавлении items")
-	ErrOrderNotFound     = errors.New("заказ не найден")
-	ErrScanRow           = errors.New("ошибка сканирования строки")
-	ErrGetItemsByOrderId = errors.New("ошибка получения items по orderID")
-	ErrItemScanFailed    = errors.New("ошибка сканирования items заказа")
-	ErrGetLastOrders     = errors.New("ошибка при получении последних заказов")
+const (
+	orderColumns = `
+		o.order_uid, o.track_number, o.entry, o.locale, o.internal_signature, o.customer_id,
+		o.delivery_service, o.shardkey, o.sm_id, o.date_created, o.oof_shard, o.status,
+
+		d.name, d.phone, d.zip, d.city, d.address, d.region, d.email,
+
+		p.transaction, p.request_id, p.currency, p.provider,
+		p.amount, p.payment_dt, p.bank, p.delivery_cost, p.goods_total, p.custom_fee`
+
+	orderFrom = `
+	FROM orders o
+	JOIN delivery d ON o.order_uid = d.order_uid
+	JOIN payment p ON o.order_uid = p.order_uid`
+
+	orderSelect = `
+	SELECT` + orderColumns + orderFrom
 )
 
 type Repository struct {
@@ -36,10 +44,10 @@ func New(db *pgxpool.Pool) *Repository {
 	}
 }
 
-func (r *Repository) SaveOrder(ctx context.Context, order *models.Order) (uuid.UUID, error) {
+func (r *Repository) SaveOrder(ctx context.Context, order *models.Order) (orderID uuid.UUID, err error) {
 	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
 	if err != nil {
-		return uuid.Nil, fmt.Errorf("backend/internal/repository/order_repo.go: %w", ErrTxBegin)
+		return uuid.Nil, wrapDBError(apperrors.ErrTxBegin, err)
 	}
 	defer func() {
 		if err != nil {
@@ -48,113 +56,156 @@ func (r *Repository) SaveOrder(ctx context.Context, order *models.Order) (uuid.U
 		}
 
 		if commitErr := tx.Commit(ctx); commitErr != nil {
-			tx.Commit(ctx)
-			err = fmt.Errorf("backend/internal/repository/order_repo.go: %w", ErrTxCommit)
+			orderID = uuid.Nil
+			err = wrapDBError(apperrors.ErrTxCommit, commitErr)
 		}
 	}()
 
-	orderQuery := `
-	INSERT INTO orders (
-		order_uid, track_number, entry, locale, internal_signature, customer_id,
-		delivery_service, shardkey, sm_id, oof_shard
-	) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-	RETURNING order_uid;
-	`
+	return saveOrder(ctx, tx, order)
+}
 
-	err = tx.QueryRow(ctx, orderQuery, order.OrderID, order.TrackNumber, order.Entry, order.Locale, order.InternalSignature,
-		order.CustomerId, order.DeliveryService, order.Shardkey, order.SmId, order.OofShard,
-	).Scan(&order.OrderID)
+func saveOrder(ctx context.Context, tx pgx.Tx, order *models.Order) (uuid.UUID, error) {
+	order.Status = models.StatusCreated
+
+	hash, err := contentHash(order)
 	if err != nil {
-		return uuid.Nil, fmt.Errorf("backend/internal/repository/order_repo.go: %w", ErrInsertOrder)
+		return uuid.Nil, fmt.Errorf("backend/internal/repository/order_repo.go, хэш заказа: %w", apperrors.ErrInsertOrder)
 	}
 
+	b := &pgx.Batch{}
+	b.Queue(`
+	INSERT INTO orders (
+		order_uid, track_number, entry, locale, internal_signature, customer_id,
+		delivery_service, shardkey, sm_id, oof_shard, content_hash, status
+	) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	ON CONFLICT (order_uid) DO NOTHING
+	RETURNING order_uid;
+	`, order.OrderID, order.TrackNumber, order.Entry, order.Locale, order.InternalSignature,
+		order.CustomerId, order.DeliveryService, order.Shardkey, order.SmId, order.OofShard, hash, models.StatusCreated)
+
 	d := order.Delivery
-	deliveryQuery := `
+	b.Queue(`
 	INSERT INTO delivery (
 	    order_uid, name, phone, zip, city, address, region, email
-	) VALUES($1, $2, $3, $4, $5, $6, $7, $8);
-	`
+	) VALUES($1, $2, $3, $4, $5, $6, $7, $8)
+	ON CONFLICT (order_uid) DO NOTHING;
+	`, order.OrderID, d.Name, d.Phone, d.Zip, d.City, d.Address, d.Region, d.Email)
 
-	_, err = tx.Exec(ctx, deliveryQuery,
-		order.OrderID, d.Name, d.Phone, d.Zip, d.City, d.Address, d.Region, d.Email)
+	p := order.Payment
+	b.Queue(`
+	INSERT INTO payment (
+		order_uid, transaction, request_id, currency, provider,
+		amount, payment_dt, bank, delivery_cost, goods_total, custom_fee
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	ON CONFLICT (order_uid) DO NOTHING;
+	`, order.OrderID, p.Transaction, p.RequestID, p.Currency, p.Provider,
+		p.Amount, p.PaymentDT, p.Bank, p.DeliveryCost, p.GoodsTotal, p.CustomFee)
+
+	payload, err := changePayload(order.OrderID, time.Now())
 	if err != nil {
-		return uuid.Nil, fmt.Errorf("backend/internal/repository/order_repo.go: %w", ErrInsertDelivery)
+		return uuid.Nil, fmt.Errorf("backend/internal/repository/order_repo.go, уведомление: %w", apperrors.ErrInsertOrder)
 	}
+	b.Queue(notifyQuery, OrderChangedChannel, payload)
 
-	p := order.Payment
-	paymentQuery := `
-		INSERT INTO payment (
-			order_uid, transaction, request_id, currency, provider,
-			amount, payment_dt, bank, delivery_cost, goods_total, custom_fee
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);
-	`
-	_, err = tx.Exec(ctx, paymentQuery,
-		order.OrderID, p.Transaction, p.RequestID, p.Currency, p.Provider,
-		p.Amount, p.PaymentDT, p.Bank, p.DeliveryCost, p.GoodsTotal, p.CustomFee)
+	event, err := outboxPayload(envelope.EventOrderCreated, envelope.CurrentOrderVersion, order)
 	if err != nil {
-		return uuid.Nil, fmt.Errorf("backend/internal/repository/order_repo.go: %w", ErrInsertPayment)
+		return uuid.Nil, fmt.Errorf("backend/internal/repository/order_repo.go, событие outbox: %w", apperrors.ErrOutbox)
 	}
+	b.Queue(outboxInsert, order.OrderID, envelope.EventOrderCreated, event)
 
-	itemQuery := `
-		INSERT INTO items (
-			order_id, chrt_id, track_number, price, rid,
-			name, sale, size, total_price, nm_id, brand, status
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12);
-	`
-	for _, item := range order.Items {
-		_, err = tx.Exec(ctx, itemQuery,
-			order.OrderID, item.ChrtID, item.TrackNumber, item.Price, item.RID,
-			item.Name, item.Sale, item.Size, item.TotalPrice, item.NmID, item.Brand, item.Status)
-		if err != nil {
-			return uuid.Nil, fmt.Errorf("backend/internal/repository/order_repo.go: %w", ErrInsertItem)
-		}
+	inserted, err := sendOrderBatch(ctx, tx, b)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if !inserted {
+		return checkDuplicate(ctx, tx, order.OrderID, hash)
+	}
+
+	_, err = tx.CopyFrom(ctx, pgx.Identifier{"items"}, itemColumns, pgx.CopyFromSlice(len(order.Items), func(i int) ([]any, error) {
+		item := order.Items[i]
+		return []any{
+			order.OrderID, i, item.ChrtID, item.TrackNumber, item.Price, item.RID,
+			item.Name, item.Sale, item.Size, item.TotalPrice, item.NmID, item.Brand, item.Status,
+		}, nil
+	}))
+	if err != nil {
+		return uuid.Nil, wrapDBError(apperrors.ErrInsertItem, err)
 	}
 
 	return order.OrderID, nil
 }
 
-func (r *Repository) GetOrderById(ctx context.Context, orderID uuid.UUID) (*models.Order, error) {
-	query := `
-	SELECT
-		o.order_uid, o.track_number, o.entry, o.locale, o.internal_signature, o.customer_id,
-		o.delivery_service, o.shardkey, o.sm_id, o.date_created, o.oof_shard,
-	
-		d.name, d.phone, d.zip, d.city, d.address, d.region, d.email,
-	
-		p.transaction, p.request_id, p.currency, p.provider,
-		p.amount, p.payment_dt, p.bank, p.delivery_cost, p.goods_total, p.custom_fee
-	FROM orders o
-	JOIN delivery d ON o.order_uid = d.order_uid
-	JOIN payment p ON o.order_uid = p.order_uid
-	WHERE o.order_uid = $1;
-	`
+var itemColumns = []string{
+	"order_id", "position", "chrt_id", "track_number", "price", "rid",
+	"name", "sale", "size", "total_price", "nm_id", "brand", "status",
+}
 
-	row := r.db.QueryRow(ctx, query, orderID)
+// sendOrderBatch отправляет вставку заказа, delivery, payment, уведомление и
+// событие outbox за один round trip. Если заказ уже есть, остальные вставки
+// ничего не делают, а уведомление и событие отбрасываются вместе с откатом
+// транзакции.
+func sendOrderBatch(ctx context.Context, tx pgx.Tx, b *pgx.Batch) (inserted bool, err error) {
+	br := tx.SendBatch(ctx, b)
+	defer func() {
+		if closeErr := br.Close(); closeErr != nil && err == nil {
+			err = wrapDBError(apperrors.ErrInsertOrder, closeErr)
+		}
+	}()
 
-	var o models.Order
-	var d models.Delivery
-	var p models.Payment
+	var orderID uuid.UUID
+	err = br.QueryRow().Scan(&orderID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, wrapDBError(apperrors.ErrInsertOrder, err)
+	}
 
-	err := row.Scan(
-		&o.OrderID, &o.TrackNumber, &o.Entry, &o.Locale, &o.InternalSignature, &o.CustomerId,
-		&o.DeliveryService, &o.Shardkey, &o.SmId, &o.DateCreated, &o.OofShard,
+	if _, err = br.Exec(); err != nil {
+		return false, wrapDBError(apperrors.ErrInsertDelivery, err)
+	}
+	if _, err = br.Exec(); err != nil {
+		return false, wrapDBError(apperrors.ErrInsertPayment, err)
+	}
+	if _, err = br.Exec(); err != nil {
+		return false, wrapDBError(apperrors.ErrInsertOrder, err)
+	}
+	if _, err = br.Exec(); err != nil {
+		return false, wrapDBError(apperrors.ErrOutbox, err)
+	}
 
-		&d.Name, &d.Phone, &d.Zip, &d.City, &d.Address, &d.Region, &d.Email,
+	return true, nil
+}
 
-		&p.Transaction, &p.RequestID, &p.Currency, &p.Provider,
-		&p.Amount, &p.PaymentDT, &p.Bank, &p.DeliveryCost, &p.GoodsTotal, &p.CustomFee,
-	)
+func checkDuplicate(ctx context.Context, tx pgx.Tx, orderID uuid.UUID, hash string) (uuid.UUID, error) {
+	var storedHash *string
+	err := tx.QueryRow(ctx, `SELECT content_hash FROM orders WHERE order_uid = $1;`, orderID).Scan(&storedHash)
+	if err != nil {
+		return uuid.Nil, wrapDBError(apperrors.ErrInsertOrder, err)
+	}
+
+	if storedHash == nil || *storedHash != hash {
+		return uuid.Nil, fmt.Errorf("backend/internal/repository/order_repo.go, order_uid %s: %w", orderID, apperrors.ErrOrderConflict)
+	}
+
+	return orderID, fmt.Errorf("backend/internal/repository/order_repo.go, order_uid %s: %w", orderID, apperrors.ErrOrderDuplicate)
+}
+
+func (r *Repository) GetOrderById(ctx context.Context, orderID uuid.UUID) (*models.Order, error) {
+	query := orderSelect + `
+	WHERE o.order_uid = $1;
+	`
+
+	var o models.Order
+	err := scanOrder(r.db.QueryRow(ctx, query, orderID), &o)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("backend/internal/repository/order_repo.go, получение заказа по id: %w", ErrOrderNotFound)
+			return nil, fmt.Errorf("backend/internal/repository/order_repo.go, получение заказа по id: %w", apperrors.ErrOrderNotFound)
 		}
 
-		return nil, fmt.Errorf("backend/internal/repository/order_repo.go, сканирование строки: %w", ErrScanRow)
+		return nil, fmt.Errorf("backend/internal/repository/order_repo.go, сканирование строки: %w", apperrors.ErrScanRow)
 	}
 
-	o.Delivery = d
-	o.Payment = p
-
 	return &o, err
 }
 
@@ -162,12 +213,13 @@ func (r *Repository) GetItemsByOrderID(ctx context.Context, orderID uuid.UUID) (
 	query := `
 	SELECT chrt_id, track_number, price, rid, name, sale, size, total_price, nm_id, brand, status
 	FROM items
-	WHERE order_id = $1;
+	WHERE order_id = $1
+	ORDER BY position;
 	`
 
 	rows, err := r.db.Query(ctx, query, orderID)
 	if err != nil {
-		return nil, fmt.Errorf("backend/internal/repository/order_repo.go, получение items по orderID: %w", ErrGetItemsByOrderId)
+		return nil, fmt.Errorf("backend/internal/repository/order_repo.go, получение items по orderID: %w", apperrors.ErrGetItemsByOrderId)
 	}
 	defer rows.Close()
 
@@ -179,73 +231,68 @@ func (r *Repository) GetItemsByOrderID(ctx context.Context, orderID uuid.UUID) (
 			&item.Size, &item.TotalPrice, &item.NmID, &item.Brand, &item.Status,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("backend/internal/repository/order_repo.go, сканирование строки item: %w", ErrItemScanFailed)
+			return nil, fmt.Errorf("backend/internal/repository/order_repo.go, сканирование строки item: %w", apperrors.ErrItemScanFailed)
 		}
 
 		items = append(items, item)
 	}
 
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("backend/internal/repository/order_repo.go, получение items по orderID: %w", apperrors.ErrGetItemsByOrderId)
+	}
+
 	return items, nil
 }
 
 func (r *Repository) GetLastOrders(ctx context.Context, limit int) ([]models.Order, error) {
-	query := `
-	SELECT
-		o.order_uid, o.track_number, o.entry, o.locale, o.internal_signature, o.customer_id,
-		o.delivery_service, o.shardkey, o.sm_id, o.date_created, o.oof_shard,
-	
-		d.name, d.phone, d.zip, d.city, d.address, d.region, d.email,
-	
-		p.transaction, p.request_id, p.currency, p.provider,
-		p.amount, p.payment_dt, p.bank, p.delivery_cost, p.goods_total, p.custom_fee
-	FROM orders o
-	JOIN delivery d ON o.order_uid = d.order_uid
-	JOIN payment p ON o.order_uid = p.order_uid
+	query := orderSelect + `
 	ORDER BY o.date_created DESC
 	LIMIT $1
 	`
 
 	rows, err := r.db.Query(ctx, query, limit)
 	if err != nil {
-		return nil, fmt.Errorf("backend/internal/repository/order_repo.go, получение последних заказов: %w", ErrGetLastOrders)
+		return nil, fmt.Errorf("backend/internal/repository/order_repo.go, получение последних заказов: %w", apperrors.ErrGetLastOrders)
 	}
 	defer rows.Close()
 
-	var orders []models.Order
-
+	orders := make([]models.Order, 0, limit)
 	for rows.Next() {
 		var o models.Order
-		var d models.Delivery
-		var p models.Payment
+		if err = scanOrder(rows, &o); err != nil {
+			return nil, fmt.Errorf("backend/internal/repository/order_repo.go, сканирование строки: %w", apperrors.ErrScanRow)
+		}
 
-		err = rows.Scan(
-			&o.OrderID, &o.TrackNumber, &o.Entry, &o.Locale, &o.InternalSignature, &o.CustomerId,
-			&o.DeliveryService, &o.Shardkey, &o.SmId, &o.DateCreated, &o.OofShard,
+		orders = append(orders, o)
+	}
 
-			&d.Name, &d.Phone, &d.Zip, &d.City, &d.Address, &d.Region, &d.Email,
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("backend/internal/repository/order_repo.go, получение последних заказов: %w", apperrors.ErrGetLastOrders)
+	}
+	rows.Close()
 
-			&p.Transaction, &p.RequestID, &p.Currency, &p.Provider,
-			&p.Amount, &p.PaymentDT, &p.Bank, &p.DeliveryCost, &p.GoodsTotal, &p.CustomFee,
-		)
-		if err != nil {
-			if errors.Is(err, pgx.ErrNoRows) {
-				return nil, fmt.Errorf("backend/internal/repository/order_repo.go, получение заказа по id: %w", ErrOrderNotFound)
-			}
+	if err = r.loadItems(ctx, orders); err != nil {
+		return nil, err
+	}
 
-			return nil, fmt.Errorf("backend/internal/repository/order_repo.go, сканирование строки: %w", ErrScanRow)
-		}
+	return orders, nil
+}
 
-		o.Delivery = d
-		o.Payment = p
+func scanOrder(row pgx.Row, o *models.Order) error {
+	return row.Scan(orderDest(o)...)
+}
 
-		items, err := r.GetItemsByOrderID(ctx, o.OrderID)
-		if err != nil {
-			return nil, fmt.Errorf("backend/internal/repository/order_repo.go, получение items по orderID: %w", ErrGetItemsByOrderId)
-		}
-		o.Items = items
+func orderDest(o *models.Order) []any {
+	d := &o.Delivery
+	p := &o.Payment
 
-		orders = append(orders, o)
-	}
+	return []any{
+		&o.OrderID, &o.TrackNumber, &o.Entry, &o.Locale, &o.InternalSignature, &o.CustomerId,
+		&o.DeliveryService, &o.Shardkey, &o.SmId, &o.DateCreated, &o.OofShard, &o.Status,
 
-	return orders, nil
+		&d.Name, &d.Phone, &d.Zip, &d.City, &d.Address, &d.Region, &d.Email,
+
+		&p.Transaction, &p.RequestID, &p.Currency, &p.Provider,
+		&p.Amount, &p.PaymentDT, &p.Bank, &p.DeliveryCost, &p.GoodsTotal, &p.CustomFee,
+	}
 }
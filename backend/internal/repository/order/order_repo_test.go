@@ -0,0 +1,71 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+)
+
+func TestRepository_SaveOrder(t *testing.T) {
+	r := testRepository(t)
+	ctx := context.Background()
+
+	order := testOrderWithItems(t, 25)
+	id, err := r.SaveOrder(ctx, order)
+	require.NoError(t, err)
+	t.Cleanup(func() { deleteTestOrder(t, r, order.OrderID) })
+	assert.Equal(t, order.OrderID, id)
+
+	got, err := r.GetFullOrder(ctx, order.OrderID)
+	require.NoError(t, err)
+	assert.Equal(t, order.Items, got.Items, "items сохранены по порядку")
+	assert.Equal(t, order.Delivery, got.Delivery)
+	assert.Equal(t, order.Payment, got.Payment)
+
+	redelivered := *order
+	id, err = r.SaveOrder(ctx, &redelivered)
+	assert.ErrorIs(t, err, apperrors.ErrOrderDuplicate)
+	assert.Equal(t, order.OrderID, id)
+
+	changed := *order
+	changed.Delivery.City = "Haifa"
+	_, err = r.SaveOrder(ctx, &changed)
+	assert.ErrorIs(t, err, apperrors.ErrOrderConflict)
+
+	got, err = r.GetFullOrder(ctx, order.OrderID)
+	require.NoError(t, err)
+	assert.Equal(t, order.Delivery, got.Delivery, "конфликт не меняет сохраненный заказ")
+}
+
+func BenchmarkRepository_SaveOrder(b *testing.B) {
+	r := testRepository(b)
+	ctx := context.Background()
+
+	for _, items := range []int{1, 10, 500} {
+		b.Run(fmt.Sprintf("items=%d", items), func(b *testing.B) {
+			var saved []uuid.UUID
+			b.Cleanup(func() {
+				for _, id := range saved {
+					deleteTestOrder(b, r, id)
+				}
+			})
+
+			for b.Loop() {
+				b.StopTimer()
+				order := testOrderWithItems(b, items)
+				b.StartTimer()
+
+				if _, err := r.SaveOrder(ctx, order); err != nil {
+					b.Fatal(err)
+				}
+				saved = append(saved, order.OrderID)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package order
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+	"github.com/avraam311/order-service/backend/internal/pkg/kafka/envelope"
+)
+
+const (
+	outboxProducerID = "order-service"
+
+	outboxInsert = `INSERT INTO outbox (aggregate_id, event_type, payload) VALUES ($1, $2, $3);`
+
+	// outboxLockKey — ключ advisory lock, чтобы события публиковал только один
+	// экземпляр relay и порядок внутри заказа не нарушался.
+	outboxLockKey = 0x6f7574626f78
+)
+
+func outboxPayload(eventType string, version int, payload any) ([]byte, error) {
+	env, err := envelope.New(eventType, version, outboxProducerID, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(env)
+}
+
+// lockOrder блокирует строку заказа до конца транзакции. Ее берет каждая
+// транзакция, которая пишет в outbox по существующему заказу: тогда id событий
+// одного заказа выдаются в порядке коммитов, и relay, публикуя по id, не
+// меняет их местами.
+func lockOrder(ctx context.Context, tx pgx.Tx, orderID uuid.UUID) (bool, error) {
+	var one int
+	err := tx.QueryRow(ctx, `SELECT 1 FROM orders WHERE order_uid = $1 FOR UPDATE;`, orderID).Scan(&one)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func writeOutbox(ctx context.Context, tx pgx.Tx, orderID uuid.UUID, eventType string, version int, payload any) error {
+	raw, err := outboxPayload(eventType, version, payload)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, outboxInsert, orderID, eventType, raw)
+	return err
+}
+
+// PublishPending берет до limit неопубликованных событий по порядку и отмечает
+// их опубликованными, только если publish вернул nil. Если события уже
+// публикует другой экземпляр, возвращает 0.
+func (r *Repository) PublishPending(ctx context.Context, limit int, publish func(ctx context.Context, events []models.OutboxEvent) error) (n int, err error) {
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return 0, wrapDBError(apperrors.ErrTxBegin, err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+			return
+		}
+
+		if commitErr := tx.Commit(ctx); commitErr != nil {
+			n = 0
+			err = wrapDBError(apperrors.ErrTxCommit, commitErr)
+		}
+	}()
+
+	var locked bool
+	if err = tx.QueryRow(ctx, `SELECT pg_try_advisory_xact_lock($1);`, outboxLockKey).Scan(&locked); err != nil {
+		return 0, wrapDBError(apperrors.ErrOutbox, err)
+	}
+	if !locked {
+		return 0, nil
+	}
+
+	rows, err := tx.Query(ctx, `
+	SELECT id, aggregate_id, event_type, payload, created_at
+	FROM outbox
+	WHERE published_at IS NULL
+	ORDER BY id
+	LIMIT $1;
+	`, limit)
+	if err != nil {
+		return 0, wrapDBError(apperrors.ErrOutbox, err)
+	}
+
+	events, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.OutboxEvent, error) {
+		var e models.OutboxEvent
+		err := row.Scan(&e.ID, &e.OrderID, &e.EventType, &e.Payload, &e.CreatedAt)
+		return e, err
+	})
+	if err != nil {
+		return 0, wrapDBError(apperrors.ErrOutbox, err)
+	}
+
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	if err = publish(ctx, events); err != nil {
+		return 0, err
+	}
+
+	ids := make([]int64, len(events))
+	for i, e := range events {
+		ids[i] = e.ID
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE outbox SET published_at = now() WHERE id = ANY($1);`, ids)
+	if err != nil {
+		return 0, wrapDBError(apperrors.ErrOutbox, err)
+	}
+
+	return len(events), nil
+}
+
+func (r *Repository) DeletePublished(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM outbox WHERE published_at IS NOT NULL AND published_at < $1;`, before)
+	if err != nil {
+		return 0, wrapDBError(apperrors.ErrOutbox, err)
+	}
+
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,123 @@
+package order
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+	"github.com/avraam311/order-service/backend/internal/pkg/kafka/envelope"
+)
+
+func TestRepository_SaveOrderWritesOutbox(t *testing.T) {
+	r := testRepository(t)
+	ctx := context.Background()
+
+	order := testOrderWithItems(t, 2)
+	_, err := r.SaveOrder(ctx, order)
+	require.NoError(t, err)
+	t.Cleanup(func() { deleteTestOrder(t, r, order.OrderID) })
+
+	_, err = r.SaveOrder(ctx, order)
+	require.ErrorIs(t, err, apperrors.ErrOrderDuplicate)
+
+	var events []models.OutboxEvent
+	_, err = r.PublishPending(ctx, 1000, func(_ context.Context, batch []models.OutboxEvent) error {
+		for _, e := range batch {
+			if e.OrderID == order.OrderID {
+				events = append(events, e)
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 1, "повторная доставка не пишет событие")
+	assert.Equal(t, envelope.EventOrderCreated, events[0].EventType)
+
+	var env envelope.Envelope
+	require.NoError(t, json.Unmarshal(events[0].Payload, &env))
+	got, err := envelope.DecodeOrder(env)
+	require.NoError(t, err)
+	assert.Equal(t, order.OrderID, got.OrderID)
+}
+
+func TestRepository_PublishPending(t *testing.T) {
+	r := testRepository(t)
+	ctx := context.Background()
+
+	first, second := testOrderWithItems(t, 1), testOrderWithItems(t, 1)
+	for _, o := range []*models.Order{first, second} {
+		_, err := r.SaveOrder(ctx, o)
+		require.NoError(t, err)
+		t.Cleanup(func() { deleteTestOrder(t, r, o.OrderID) })
+	}
+
+	require.NoError(t, r.UpdateOrderStatus(ctx, models.StatusChange{
+		OrderID: first.OrderID, From: models.StatusCreated, To: models.StatusPaid, ChangedBy: "test", ChangedAt: time.Now(),
+	}))
+	require.NoError(t, r.UpdatePayment(ctx, second.OrderID, second.Payment))
+	require.NoError(t, r.UpdatePayment(ctx, first.OrderID, first.Payment))
+
+	ours := func(events []models.OutboxEvent) []models.OutboxEvent {
+		var got []models.OutboxEvent
+		for _, e := range events {
+			if e.OrderID == first.OrderID || e.OrderID == second.OrderID {
+				got = append(got, e)
+			}
+		}
+		return got
+	}
+
+	_, err := r.PublishPending(ctx, 1000, func(context.Context, []models.OutboxEvent) error {
+		return errors.New("broker down")
+	})
+	require.Error(t, err)
+
+	var published []models.OutboxEvent
+	_, err = r.PublishPending(ctx, 1000, func(ctx context.Context, events []models.OutboxEvent) error {
+		n, err := r.PublishPending(ctx, 1000, func(context.Context, []models.OutboxEvent) error {
+			t.Error("второй relay не должен публиковать, пока первый держит блокировку")
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Zero(t, n)
+
+		published = ours(events)
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.Len(t, published, 5, "ошибка публикации оставила события неопубликованными")
+	for i := 1; i < len(published); i++ {
+		assert.Less(t, published[i-1].ID, published[i].ID, "события идут по id")
+	}
+
+	typesOf := func(orderID uuid.UUID) []string {
+		var types []string
+		for _, e := range published {
+			if e.OrderID == orderID {
+				types = append(types, e.EventType)
+			}
+		}
+		return types
+	}
+	assert.Equal(t, []string{envelope.EventOrderCreated, envelope.EventOrderStatusChanged, envelope.EventPaymentUpdated}, typesOf(first.OrderID))
+	assert.Equal(t, []string{envelope.EventOrderCreated, envelope.EventPaymentUpdated}, typesOf(second.OrderID))
+
+	_, err = r.PublishPending(ctx, 1000, func(_ context.Context, events []models.OutboxEvent) error {
+		assert.Empty(t, ours(events), "опубликованные события не отдаются повторно")
+		return nil
+	})
+	require.NoError(t, err)
+
+	deleted, err := r.DeletePublished(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, deleted, int64(5))
+}
@@ -0,0 +1,69 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+	"github.com/avraam311/order-service/backend/internal/pkg/kafka/envelope"
+)
+
+func (r *Repository) UpdatePayment(ctx context.Context, orderID uuid.UUID, p models.Payment) (err error) {
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return wrapDBError(apperrors.ErrTxBegin, err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+			return
+		}
+
+		if commitErr := tx.Commit(ctx); commitErr != nil {
+			err = wrapDBError(apperrors.ErrTxCommit, commitErr)
+		}
+	}()
+
+	found, err := lockOrder(ctx, tx, orderID)
+	if err != nil {
+		return wrapDBError(apperrors.ErrUpdatePayment, err)
+	}
+	if !found {
+		return fmt.Errorf("backend/internal/repository/payment_repo.go, order_uid %s: %w", orderID, apperrors.ErrOrderNotFound)
+	}
+
+	query := `
+	UPDATE payment SET
+		transaction = $2, request_id = $3, currency = $4, provider = $5, amount = $6,
+		payment_dt = $7, bank = $8, delivery_cost = $9, goods_total = $10, custom_fee = $11
+	WHERE order_uid = $1;
+	`
+	tag, err := tx.Exec(ctx, query, orderID, p.Transaction, p.RequestID, p.Currency, p.Provider,
+		p.Amount, p.PaymentDT, p.Bank, p.DeliveryCost, p.GoodsTotal, p.CustomFee)
+	if err != nil {
+		return wrapDBError(apperrors.ErrUpdatePayment, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("backend/internal/repository/payment_repo.go, order_uid %s: %w", orderID, apperrors.ErrOrderNotFound)
+	}
+
+	if err = notifyChanged(ctx, tx, orderID, time.Now()); err != nil {
+		return wrapDBError(apperrors.ErrUpdatePayment, err)
+	}
+
+	err = writeOutbox(ctx, tx, orderID, envelope.EventPaymentUpdated, envelope.CurrentEventVersion, envelope.PaymentUpdated{
+		OrderID: orderID,
+		Payment: p,
+	})
+	if err != nil {
+		return wrapDBError(apperrors.ErrOutbox, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package order
+
+import (
+	"fmt"
+	"strings"
+)
+
+type queryBuilder struct {
+	conds []string
+	args  []any
+}
+
+func (b *queryBuilder) arg(v any) string {
+	b.args = append(b.args, v)
+	return fmt.Sprintf("$%d", len(b.args))
+}
+
+func (b *queryBuilder) where(format string, args ...any) {
+	placeholders := make([]any, len(args))
+	for i, a := range args {
+		placeholders[i] = b.arg(a)
+	}
+
+	b.conds = append(b.conds, fmt.Sprintf(format, placeholders...))
+}
+
+func (b *queryBuilder) whereEq(column string, value string) {
+	if value == "" {
+		return
+	}
+
+	b.where(column+" = %s", value)
+}
+
+func (b *queryBuilder) whereClause() string {
+	if len(b.conds) == 0 {
+		return ""
+	}
+
+	return "\n\tWHERE " + strings.Join(b.conds, "\n\t  AND ")
+}
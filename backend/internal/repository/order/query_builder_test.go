@@ -0,0 +1,23 @@
+package order
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryBuilder(t *testing.T) {
+	var b queryBuilder
+	assert.Empty(t, b.whereClause(), "без условий WHERE не добавляется")
+
+	b.whereEq("o.customer_id", "")
+	assert.Empty(t, b.args, "пустое значение не фильтрует")
+
+	b.whereEq("o.customer_id", "test")
+	b.where("(p.amount, o.order_uid) > (%s, %s)", 100, "id")
+	limit := b.arg(11)
+
+	assert.Equal(t, "\n\tWHERE o.customer_id = $1\n\t  AND (p.amount, o.order_uid) > ($2, $3)", b.whereClause())
+	assert.Equal(t, "$4", limit)
+	assert.Equal(t, []any{"test", 100, "id", 11}, b.args)
+}
@@ -0,0 +1,74 @@
+package order
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+	"github.com/avraam311/order-service/backend/internal/pkg/kafka/envelope"
+)
+
+func (r *Repository) UpdateOrderStatus(ctx context.Context, change models.StatusChange) (err error) {
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return wrapDBError(apperrors.ErrTxBegin, err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+			return
+		}
+
+		if commitErr := tx.Commit(ctx); commitErr != nil {
+			err = wrapDBError(apperrors.ErrTxCommit, commitErr)
+		}
+	}()
+
+	found, err := lockOrder(ctx, tx, change.OrderID)
+	if err != nil {
+		return wrapDBError(apperrors.ErrUpdateStatus, err)
+	}
+	if !found {
+		return fmt.Errorf("backend/internal/repository/status_repo.go, order_uid %s: %w", change.OrderID, apperrors.ErrOrderNotFound)
+	}
+
+	tag, err := tx.Exec(ctx, `UPDATE orders SET status = $1 WHERE order_uid = $2 AND status = $3;`,
+		change.To, change.OrderID, change.From)
+	if err != nil {
+		return wrapDBError(apperrors.ErrUpdateStatus, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("backend/internal/repository/status_repo.go, order_uid %s, статус %s: %w", change.OrderID, change.From, apperrors.ErrStatusConflict)
+	}
+
+	historyQuery := `
+	INSERT INTO order_status_history (
+		order_uid, from_status, to_status, changed_by, reason, changed_at
+	) VALUES ($1, $2, $3, $4, $5, $6);
+	`
+	_, err = tx.Exec(ctx, historyQuery,
+		change.OrderID, change.From, change.To, change.ChangedBy, change.Reason, change.ChangedAt)
+	if err != nil {
+		return wrapDBError(apperrors.ErrUpdateStatus, err)
+	}
+
+	if err = notifyChanged(ctx, tx, change.OrderID, change.ChangedAt); err != nil {
+		return wrapDBError(apperrors.ErrUpdateStatus, err)
+	}
+
+	err = writeOutbox(ctx, tx, change.OrderID, envelope.EventOrderStatusChanged, envelope.CurrentEventVersion, envelope.StatusChanged{
+		OrderID:   change.OrderID,
+		Status:    change.To,
+		ChangedBy: change.ChangedBy,
+		Reason:    change.Reason,
+	})
+	if err != nil {
+		return wrapDBError(apperrors.ErrOutbox, err)
+	}
+
+	return nil
+}
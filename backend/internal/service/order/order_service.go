@@ -2,64 +2,254 @@ package order
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sync"
 
 	"github.com/google/uuid"
+	"golang.org/x/sync/singleflight"
 
 	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
 )
 
 type orderRepository interface {
 	SaveOrder(ctx context.Context, order *models.Order) (uuid.UUID, error)
+	SaveOrders(ctx context.Context, orders []*models.Order) ([]error, error)
 	GetOrderById(ctx context.Context, orderID uuid.UUID) (*models.Order, error)
-	GetItemsByOrderID(ctx context.Context, orderID uuid.UUID) ([]models.Item, error)
+	GetFullOrder(ctx context.Context, orderID uuid.UUID) (*models.Order, error)
+	GetOrdersByIDs(ctx context.Context, orderIDs []uuid.UUID) ([]models.Order, error)
+	ListOrders(ctx context.Context, f models.OrderFilter) (*models.OrderPage, error)
+	UpdateOrderStatus(ctx context.Context, change models.StatusChange) error
+	UpdatePayment(ctx context.Context, orderID uuid.UUID, p models.Payment) error
 }
 
 type orderCache interface {
 	Get(orderID uuid.UUID) (*models.Order, bool)
 	Set(orderID uuid.UUID, order *models.Order)
+	SetNotFound(orderID uuid.UUID)
+	Delete(orderID uuid.UUID)
+}
+
+// pendingLoad отмечает, что заказ сейчас читается из бд и что за время чтения
+// его успели инвалидировать.
+type pendingLoad struct {
+	refs        int
+	invalidated bool
 }
 
 type Service struct {
 	cache orderCache
 	repo  orderRepository
+	loads singleflight.Group
+
+	mu      sync.Mutex
+	pending map[uuid.UUID]*pendingLoad
 }
 
 func New(c orderCache, repo orderRepository) *Service {
 	return &Service{
-		cache: c,
-		repo:  repo,
+		cache:   c,
+		repo:    repo,
+		pending: make(map[uuid.UUID]*pendingLoad),
+	}
+}
+
+// InvalidateCache удаляет заказ из кэша. Загрузки этого заказа, идущие в этот
+// момент, уже ничего не запишут в кэш: заказ мог появиться или измениться
+// после их чтения.
+func (s *Service) InvalidateCache(orderID uuid.UUID) {
+	if s.cache == nil {
+		return
+	}
+
+	s.mu.Lock()
+	if p, ok := s.pending[orderID]; ok {
+		p.invalidated = true
+	}
+	s.mu.Unlock()
+
+	s.cache.Delete(orderID)
+}
+
+func (s *Service) beginLoad(orderIDs ...uuid.UUID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, id := range orderIDs {
+		p, ok := s.pending[id]
+		if !ok {
+			p = &pendingLoad{}
+			s.pending[id] = p
+		}
+		p.refs++
+	}
+}
+
+func (s *Service) endLoad(orderIDs ...uuid.UUID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, id := range orderIDs {
+		p, ok := s.pending[id]
+		if !ok {
+			continue
+		}
+
+		p.refs--
+		if p.refs == 0 {
+			delete(s.pending, id)
+		}
+	}
+}
+
+// storeLoaded кладет в кэш результат загрузки (nil — заказ не найден), только
+// если заказ не инвалидировали с начала загрузки: иначе прочитанная строка
+// могла устареть. Запись идет под s.mu, поэтому инвалидация либо отменит ее,
+// либо удалит ее следом.
+func (s *Service) storeLoaded(orderID uuid.UUID, order *models.Order) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p, ok := s.pending[orderID]; ok && p.invalidated {
+		return
+	}
+
+	if order == nil {
+		s.cache.SetNotFound(orderID)
+		return
 	}
+
+	s.cache.Set(orderID, order)
 }
 
 func (s *Service) SaveOrder(ctx context.Context, order *models.Order) (uuid.UUID, error) {
 	orderID, err := s.repo.SaveOrder(ctx, order)
+	if errors.Is(err, apperrors.ErrOrderDuplicate) {
+		return orderID, err
+	}
 	if err != nil {
 		return uuid.Nil, err
 	}
 
+	s.InvalidateCache(orderID)
+
 	return orderID, nil
 }
 
+func (s *Service) SaveOrders(ctx context.Context, orders []*models.Order) ([]error, error) {
+	results, err := s.repo.SaveOrders(ctx, orders)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, order := range orders {
+		if results[i] == nil {
+			s.InvalidateCache(order.OrderID)
+		}
+	}
+
+	return results, nil
+}
+
 func (s *Service) GetOrderByID(ctx context.Context, orderID uuid.UUID) (*models.Order, error) {
 	if s.cache != nil {
 		if order, found := s.cache.Get(orderID); found {
+			if order == nil {
+				return nil, fmt.Errorf("backend/internal/service/order/order_service.go, order_uid %s: %w", orderID, apperrors.ErrOrderNotFound)
+			}
+
 			return order, nil
 		}
 	}
 
-	order, err := s.repo.GetOrderById(ctx, orderID)
-	if err != nil {
-		return nil, err
+	ch := s.loads.DoChan(orderID.String(), func() (interface{}, error) {
+		return s.loadOrder(context.WithoutCancel(ctx), orderID)
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+
+		return res.Val.(*models.Order), nil
 	}
+}
+
+func (s *Service) loadOrder(ctx context.Context, orderID uuid.UUID) (*models.Order, error) {
+	s.beginLoad(orderID)
+	defer s.endLoad(orderID)
 
-	items, err := s.repo.GetItemsByOrderID(ctx, orderID)
+	order, err := s.repo.GetFullOrder(ctx, orderID)
+	if errors.Is(err, apperrors.ErrOrderNotFound) && s.cache != nil {
+		s.storeLoaded(orderID, nil)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	order.Items = items
-
-	s.cache.Set(orderID, order)
+	if s.cache != nil {
+		s.storeLoaded(orderID, order)
+	}
 
 	return order, nil
 }
+
+func (s *Service) GetOrdersByIDs(ctx context.Context, orderIDs []uuid.UUID) ([]models.Order, error) {
+	found := make(map[uuid.UUID]*models.Order, len(orderIDs))
+	misses := make([]uuid.UUID, 0, len(orderIDs))
+	for _, id := range orderIDs {
+		if s.cache != nil {
+			if order, ok := s.cache.Get(id); ok {
+				if order != nil {
+					found[id] = order
+				}
+				continue
+			}
+		}
+
+		misses = append(misses, id)
+	}
+
+	if len(misses) > 0 {
+		s.beginLoad(misses...)
+		defer s.endLoad(misses...)
+
+		loaded, err := s.repo.GetOrdersByIDs(ctx, misses)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range loaded {
+			order := &loaded[i]
+			found[order.OrderID] = order
+			if s.cache != nil {
+				s.storeLoaded(order.OrderID, order)
+			}
+		}
+
+		if s.cache != nil {
+			for _, id := range misses {
+				if _, ok := found[id]; !ok {
+					s.storeLoaded(id, nil)
+				}
+			}
+		}
+	}
+
+	orders := make([]models.Order, 0, len(found))
+	for _, id := range orderIDs {
+		if order, ok := found[id]; ok {
+			orders = append(orders, *order)
+		}
+	}
+
+	return orders, nil
+}
+
+func (s *Service) ListOrders(ctx context.Context, f models.OrderFilter) (*models.OrderPage, error) {
+	return s.repo.ListOrders(ctx, f)
+}
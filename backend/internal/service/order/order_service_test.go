@@ -3,7 +3,11 @@ package order
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
@@ -11,15 +15,17 @@ import (
 
 	mock_repository "github.com/avraam311/order-service/backend/internal/mocks/repository"
 	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
 )
 
 func TestService_SaveOrder(t *testing.T) {
 	t.Helper()
 	tests := []struct {
-		name    string
-		setup   func(*gomock.Controller) (*Service, *mock_repository.MockorderRepository)
-		wantID  uuid.UUID
-		wantErr bool
+		name       string
+		setup      func(*gomock.Controller) (*Service, *mock_repository.MockorderRepository)
+		wantID     uuid.UUID
+		wantErr    bool
+		wantKeepID bool
 	}{
 		{
 			name: "успешное сохранение заказа",
@@ -31,6 +37,30 @@ func TestService_SaveOrder(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "повторное сохранение возвращает id",
+			setup: func(ctrl *gomock.Controller) (*Service, *mock_repository.MockorderRepository) {
+				mockRepo := mock_repository.NewMockorderRepository(ctrl)
+				mockRepo.EXPECT().SaveOrder(gomock.Any(), gomock.Any()).Return(uuid.New(), fmt.Errorf("repo: %w", apperrors.ErrOrderDuplicate))
+				srv := New(nil, mockRepo)
+				return srv, mockRepo
+			},
+			wantErr:    true,
+			wantKeepID: true,
+		},
+		{
+			name: "сохранение сбрасывает запись в кэше",
+			setup: func(ctrl *gomock.Controller) (*Service, *mock_repository.MockorderRepository) {
+				orderID := uuid.New()
+				mockRepo := mock_repository.NewMockorderRepository(ctrl)
+				mockCache := mock_repository.NewMockorderCache(ctrl)
+				mockRepo.EXPECT().SaveOrder(gomock.Any(), gomock.Any()).Return(orderID, nil)
+				mockCache.EXPECT().Delete(orderID)
+				srv := New(mockCache, mockRepo)
+				return srv, mockRepo
+			},
+			wantErr: false,
+		},
 		{
 			name: "ошибка репозитория",
 			setup: func(ctrl *gomock.Controller) (*Service, *mock_repository.MockorderRepository) {
@@ -55,7 +85,7 @@ func TestService_SaveOrder(t *testing.T) {
 
 			if tt.wantErr {
 				assert.Error(t, err)
-				assert.Equal(t, uuid.Nil, id)
+				assert.Equal(t, tt.wantKeepID, id != uuid.Nil)
 			} else {
 				assert.NoError(t, err)
 				assert.NotEqual(t, uuid.Nil, id)
@@ -64,6 +94,58 @@ func TestService_SaveOrder(t *testing.T) {
 	}
 }
 
+func TestService_SaveOrders(t *testing.T) {
+	saved := &models.Order{OrderID: uuid.New()}
+	failed := &models.Order{OrderID: uuid.New()}
+	orders := []*models.Order{saved, failed}
+
+	tests := []struct {
+		name    string
+		setup   func(*gomock.Controller) *Service
+		wantErr bool
+	}{
+		{
+			name: "кэш сбрасывается только для сохраненных заказов",
+			setup: func(ctrl *gomock.Controller) *Service {
+				mockRepo := mock_repository.NewMockorderRepository(ctrl)
+				mockCache := mock_repository.NewMockorderCache(ctrl)
+				mockRepo.EXPECT().SaveOrders(gomock.Any(), orders).
+					Return([]error{nil, fmt.Errorf("repo: %w", apperrors.ErrOrderConflict)}, nil)
+				mockCache.EXPECT().Delete(saved.OrderID)
+				return New(mockCache, mockRepo)
+			},
+		},
+		{
+			name: "ошибка транзакции",
+			setup: func(ctrl *gomock.Controller) *Service {
+				mockRepo := mock_repository.NewMockorderRepository(ctrl)
+				mockCache := mock_repository.NewMockorderCache(ctrl)
+				mockRepo.EXPECT().SaveOrders(gomock.Any(), orders).Return(nil, fmt.Errorf("repo: %w", apperrors.ErrDBConnection))
+				return New(mockCache, mockRepo)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			results, err := tt.setup(ctrl).SaveOrders(context.Background(), orders)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, results, len(orders))
+			assert.NoError(t, results[0])
+			assert.ErrorIs(t, results[1], apperrors.ErrOrderConflict)
+		})
+	}
+}
+
 func TestService_GetOrderByID(t *testing.T) {
 	t.Helper()
 	orderID := uuid.New()
@@ -96,8 +178,7 @@ func TestService_GetOrderByID(t *testing.T) {
 				mockRepo := mock_repository.NewMockorderRepository(ctrl)
 				mockCache := mock_repository.NewMockorderCache(ctrl)
 				mockCache.EXPECT().Get(orderID).Return((*models.Order)(nil), false)
-				mockRepo.EXPECT().GetOrderById(gomock.Any(), orderID).Return(&models.Order{OrderID: orderID}, nil)
-				mockRepo.EXPECT().GetItemsByOrderID(gomock.Any(), orderID).Return(sampleItems, nil)
+				mockRepo.EXPECT().GetFullOrder(gomock.Any(), orderID).Return(&models.Order{OrderID: orderID, Items: sampleItems}, nil)
 				mockCache.EXPECT().Set(orderID, gomock.Any())
 				srv := New(mockCache, mockRepo)
 				return srv, mockRepo, mockCache
@@ -105,30 +186,74 @@ func TestService_GetOrderByID(t *testing.T) {
 			want: &models.Order{OrderID: orderID, Items: sampleItems},
 		},
 		{
-			name: "repo GetOrderById error",
+			name: "repo GetFullOrder error",
 			setup: func(ctrl *gomock.Controller) (*Service, *mock_repository.MockorderRepository, *mock_repository.MockorderCache) {
 				mockRepo := mock_repository.NewMockorderRepository(ctrl)
 				mockCache := mock_repository.NewMockorderCache(ctrl)
 				mockCache.EXPECT().Get(orderID).Return(nil, false)
-				mockRepo.EXPECT().GetOrderById(gomock.Any(), orderID).Return(nil, errors.New("not found"))
+				mockRepo.EXPECT().GetFullOrder(gomock.Any(), orderID).Return(nil, errors.New("not found"))
 				srv := New(mockCache, mockRepo)
 				return srv, mockRepo, mockCache
 			},
 			wantErr: true,
 		},
 		{
-			name: "repo GetItemsByOrderID error",
+			name: "заказ в отрицательном кэше",
+			setup: func(ctrl *gomock.Controller) (*Service, *mock_repository.MockorderRepository, *mock_repository.MockorderCache) {
+				mockCache := mock_repository.NewMockorderCache(ctrl)
+				mockCache.EXPECT().Get(orderID).Return(nil, true)
+				srv := New(mockCache, nil)
+				return srv, nil, mockCache
+			},
+			wantErr: true,
+		},
+		{
+			name: "не найденный заказ попадает в отрицательный кэш",
 			setup: func(ctrl *gomock.Controller) (*Service, *mock_repository.MockorderRepository, *mock_repository.MockorderCache) {
 				mockRepo := mock_repository.NewMockorderRepository(ctrl)
 				mockCache := mock_repository.NewMockorderCache(ctrl)
 				mockCache.EXPECT().Get(orderID).Return(nil, false)
-				mockRepo.EXPECT().GetOrderById(gomock.Any(), orderID).Return(&models.Order{OrderID: orderID}, nil)
-				mockRepo.EXPECT().GetItemsByOrderID(gomock.Any(), orderID).Return(nil, errors.New("items error"))
+				mockRepo.EXPECT().GetFullOrder(gomock.Any(), orderID).Return(nil, fmt.Errorf("repo: %w", apperrors.ErrOrderNotFound))
+				mockCache.EXPECT().SetNotFound(orderID)
+				srv := New(mockCache, mockRepo)
+				return srv, mockRepo, mockCache
+			},
+			wantErr: true,
+		},
+		{
+			name: "заказ создан во время загрузки",
+			setup: func(ctrl *gomock.Controller) (*Service, *mock_repository.MockorderRepository, *mock_repository.MockorderCache) {
+				mockRepo := mock_repository.NewMockorderRepository(ctrl)
+				mockCache := mock_repository.NewMockorderCache(ctrl)
 				srv := New(mockCache, mockRepo)
+				mockCache.EXPECT().Get(orderID).Return(nil, false)
+				mockRepo.EXPECT().GetFullOrder(gomock.Any(), orderID).DoAndReturn(
+					func(context.Context, uuid.UUID) (*models.Order, error) {
+						srv.InvalidateCache(orderID)
+						return nil, fmt.Errorf("repo: %w", apperrors.ErrOrderNotFound)
+					})
+				mockCache.EXPECT().Delete(orderID)
 				return srv, mockRepo, mockCache
 			},
 			wantErr: true,
 		},
+		{
+			name: "заказ изменен во время загрузки",
+			setup: func(ctrl *gomock.Controller) (*Service, *mock_repository.MockorderRepository, *mock_repository.MockorderCache) {
+				mockRepo := mock_repository.NewMockorderRepository(ctrl)
+				mockCache := mock_repository.NewMockorderCache(ctrl)
+				srv := New(mockCache, mockRepo)
+				mockCache.EXPECT().Get(orderID).Return(nil, false)
+				mockRepo.EXPECT().GetFullOrder(gomock.Any(), orderID).DoAndReturn(
+					func(context.Context, uuid.UUID) (*models.Order, error) {
+						srv.InvalidateCache(orderID)
+						return &models.Order{OrderID: orderID, Items: sampleItems}, nil
+					})
+				mockCache.EXPECT().Delete(orderID)
+				return srv, mockRepo, mockCache
+			},
+			want: &models.Order{OrderID: orderID, Items: sampleItems},
+		},
 	}
 
 	for _, tt := range tests {
@@ -147,6 +272,183 @@ func TestService_GetOrderByID(t *testing.T) {
 				assert.NoError(t, err)
 				assert.Equal(t, tt.want.OrderID, order.OrderID)
 			}
+			assert.Empty(t, srv.pending)
+		})
+	}
+}
+
+func TestService_GetOrderByID_CoalescesConcurrentMisses(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderID := uuid.New()
+	release := make(chan struct{})
+
+	mockRepo := mock_repository.NewMockorderRepository(ctrl)
+	mockRepo.EXPECT().GetFullOrder(gomock.Any(), orderID).DoAndReturn(
+		func(context.Context, uuid.UUID) (*models.Order, error) {
+			<-release
+			return &models.Order{OrderID: orderID, Items: []models.Item{{ChrtID: 1}}}, nil
+		}).Times(1)
+
+	srv := New(nil, mockRepo)
+
+	const callers = 20
+	var wg sync.WaitGroup
+	results := make(chan *models.Order, callers)
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			order, err := srv.GetOrderByID(context.Background(), orderID)
+			assert.NoError(t, err)
+			results <- order
+		}()
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(results)
+
+	for order := range results {
+		assert.Equal(t, orderID, order.OrderID)
+		assert.Len(t, order.Items, 1)
+	}
+}
+
+func TestService_GetOrderByID_CallerCancelled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderID := uuid.New()
+	release := make(chan struct{})
+	defer close(release)
+
+	mockRepo := mock_repository.NewMockorderRepository(ctrl)
+	mockRepo.EXPECT().GetFullOrder(gomock.Any(), orderID).DoAndReturn(
+		func(context.Context, uuid.UUID) (*models.Order, error) {
+			<-release
+			return nil, apperrors.ErrOrderNotFound
+		}).AnyTimes()
+
+	srv := New(nil, mockRepo)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := srv.GetOrderByID(ctx, orderID)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func BenchmarkService_GetOrderByID_ParallelMisses(b *testing.B) {
+	orderID := uuid.New()
+
+	benchmarks := []struct {
+		name string
+		get  func(srv *Service, ctx context.Context) (*models.Order, error)
+	}{
+		{
+			name: "без объединения",
+			get: func(srv *Service, ctx context.Context) (*models.Order, error) {
+				return srv.loadOrder(ctx, orderID)
+			},
+		},
+		{
+			name: "singleflight",
+			get: func(srv *Service, ctx context.Context) (*models.Order, error) {
+				return srv.GetOrderByID(ctx, orderID)
+			},
+		},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			ctrl := gomock.NewController(b)
+			defer ctrl.Finish()
+
+			var loads atomic.Int64
+			mockRepo := mock_repository.NewMockorderRepository(ctrl)
+			mockRepo.EXPECT().GetFullOrder(gomock.Any(), orderID).DoAndReturn(
+				func(context.Context, uuid.UUID) (*models.Order, error) {
+					loads.Add(1)
+					time.Sleep(time.Millisecond)
+					return &models.Order{OrderID: orderID}, nil
+				}).AnyTimes()
+
+			srv := New(nil, mockRepo)
+			ctx := context.Background()
+
+			b.SetParallelism(16)
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if _, err := bm.get(srv, ctx); err != nil {
+						b.Error(err)
+					}
+				}
+			})
+
+			b.ReportMetric(float64(loads.Load())/float64(b.N), "loads/op")
 		})
 	}
 }
+
+func TestService_GetOrdersByIDs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cached, negative, loaded, missing := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	mockCache := mock_repository.NewMockorderCache(ctrl)
+	mockCache.EXPECT().Get(cached).Return(&models.Order{OrderID: cached}, true)
+	mockCache.EXPECT().Get(negative).Return(nil, true)
+	mockCache.EXPECT().Get(loaded).Return(nil, false)
+	mockCache.EXPECT().Get(missing).Return(nil, false)
+	mockCache.EXPECT().Set(loaded, gomock.Any())
+	mockCache.EXPECT().SetNotFound(missing)
+
+	mockRepo := mock_repository.NewMockorderRepository(ctrl)
+	mockRepo.EXPECT().GetOrdersByIDs(gomock.Any(), []uuid.UUID{loaded, missing}).
+		Return([]models.Order{{OrderID: loaded, Items: []models.Item{{ChrtID: 1}}}}, nil)
+
+	orders, err := New(mockCache, mockRepo).GetOrdersByIDs(context.Background(), []uuid.UUID{loaded, negative, cached, missing})
+	assert.NoError(t, err)
+
+	if assert.Len(t, orders, 2) {
+		assert.Equal(t, loaded, orders[0].OrderID, "порядок как в запросе")
+		assert.Len(t, orders[0].Items, 1)
+		assert.Equal(t, cached, orders[1].OrderID)
+	}
+}
+
+func TestService_GetOrdersByIDs_InvalidatedDuringLoad(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	created, changed, missing := uuid.New(), uuid.New(), uuid.New()
+
+	mockCache := mock_repository.NewMockorderCache(ctrl)
+	mockRepo := mock_repository.NewMockorderRepository(ctrl)
+	srv := New(mockCache, mockRepo)
+
+	mockCache.EXPECT().Get(created).Return(nil, false)
+	mockCache.EXPECT().Get(changed).Return(nil, false)
+	mockCache.EXPECT().Get(missing).Return(nil, false)
+	mockRepo.EXPECT().GetOrdersByIDs(gomock.Any(), []uuid.UUID{created, changed, missing}).DoAndReturn(
+		func(context.Context, []uuid.UUID) ([]models.Order, error) {
+			srv.InvalidateCache(created)
+			srv.InvalidateCache(changed)
+			return []models.Order{{OrderID: changed}}, nil
+		})
+	mockCache.EXPECT().Delete(created)
+	mockCache.EXPECT().Delete(changed)
+	mockCache.EXPECT().SetNotFound(missing)
+
+	orders, err := srv.GetOrdersByIDs(context.Background(), []uuid.UUID{created, changed, missing})
+	assert.NoError(t, err)
+	if assert.Len(t, orders, 1) {
+		assert.Equal(t, changed, orders[0].OrderID, "заказ отдается, но в кэш не кладется")
+	}
+	assert.Empty(t, srv.pending)
+}
@@ -0,0 +1,19 @@
+package order
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/avraam311/order-service/backend/internal/models"
+)
+
+func (s *Service) UpdatePayment(ctx context.Context, orderID uuid.UUID, payment models.Payment) error {
+	if err := s.repo.UpdatePayment(ctx, orderID, payment); err != nil {
+		return err
+	}
+
+	s.InvalidateCache(orderID)
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	mock_repository "github.com/avraam311/order-service/backend/internal/mocks/repository"
+	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+)
+
+func TestService_UpdatePayment(t *testing.T) {
+	orderID := uuid.New()
+	payment := models.Payment{Transaction: "tx-2", Currency: "USD", Provider: "wbpay", Amount: 2000}
+
+	tests := []struct {
+		name    string
+		setup   func(*mock_repository.MockorderRepository, *mock_repository.MockorderCache)
+		wantErr error
+	}{
+		{
+			name: "оплата обновлена и кэш сброшен",
+			setup: func(repo *mock_repository.MockorderRepository, c *mock_repository.MockorderCache) {
+				repo.EXPECT().UpdatePayment(gomock.Any(), orderID, payment).Return(nil)
+				c.EXPECT().Delete(orderID)
+			},
+		},
+		{
+			name: "заказ не найден",
+			setup: func(repo *mock_repository.MockorderRepository, _ *mock_repository.MockorderCache) {
+				repo.EXPECT().UpdatePayment(gomock.Any(), orderID, payment).Return(apperrors.ErrOrderNotFound)
+			},
+			wantErr: apperrors.ErrOrderNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock_repository.NewMockorderRepository(ctrl)
+			c := mock_repository.NewMockorderCache(ctrl)
+			tt.setup(repo, c)
+
+			err := New(c, repo).UpdatePayment(context.Background(), orderID, payment)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
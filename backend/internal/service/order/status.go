@@ -0,0 +1,69 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+)
+
+var transitions = map[models.OrderStatus][]models.OrderStatus{
+	models.StatusCreated:    {models.StatusPaid, models.StatusCancelled},
+	models.StatusPaid:       {models.StatusAssembling, models.StatusCancelled},
+	models.StatusAssembling: {models.StatusShipped, models.StatusCancelled},
+	models.StatusShipped:    {models.StatusDelivered, models.StatusReturned},
+	models.StatusDelivered:  {models.StatusReturned},
+	models.StatusCancelled:  nil,
+	models.StatusReturned:   nil,
+}
+
+func validStatus(status models.OrderStatus) bool {
+	_, ok := transitions[status]
+	return ok
+}
+
+func canTransition(from, to models.OrderStatus) bool {
+	return slices.Contains(transitions[from], to)
+}
+
+func (s *Service) ChangeStatus(ctx context.Context, orderID uuid.UUID, to models.OrderStatus, changedBy, reason string) (*models.StatusChange, error) {
+	if !validStatus(to) {
+		return nil, fmt.Errorf("backend/internal/service/order/status.go, статус %q: %w", to, apperrors.ErrInvalidStatus)
+	}
+
+	order, err := s.repo.GetOrderById(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	change := models.StatusChange{
+		OrderID:   orderID,
+		From:      order.Status,
+		To:        to,
+		ChangedBy: changedBy,
+		Reason:    reason,
+		ChangedAt: time.Now().UTC(),
+	}
+
+	// Повторная доставка уже примененного события ничего не меняет.
+	if order.Status == to {
+		return &change, nil
+	}
+
+	if !canTransition(order.Status, to) {
+		return nil, fmt.Errorf("backend/internal/service/order/status.go, %s -> %s: %w", order.Status, to, apperrors.ErrIllegalStatusTransition)
+	}
+
+	if err = s.repo.UpdateOrderStatus(ctx, change); err != nil {
+		return nil, err
+	}
+
+	s.InvalidateCache(orderID)
+
+	return &change, nil
+}
@@ -0,0 +1,126 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mock_repository "github.com/avraam311/order-service/backend/internal/mocks/repository"
+	"github.com/avraam311/order-service/backend/internal/models"
+	"github.com/avraam311/order-service/backend/internal/pkg/apperrors"
+)
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from models.OrderStatus
+		to   models.OrderStatus
+		want bool
+	}{
+		{models.StatusCreated, models.StatusPaid, true},
+		{models.StatusCreated, models.StatusShipped, false},
+		{models.StatusPaid, models.StatusAssembling, true},
+		{models.StatusAssembling, models.StatusShipped, true},
+		{models.StatusShipped, models.StatusCancelled, false},
+		{models.StatusShipped, models.StatusDelivered, true},
+		{models.StatusDelivered, models.StatusReturned, true},
+		{models.StatusCancelled, models.StatusPaid, false},
+		{models.StatusReturned, models.StatusDelivered, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			assert.Equal(t, tt.want, canTransition(tt.from, tt.to))
+		})
+	}
+}
+
+func TestService_ChangeStatus(t *testing.T) {
+	orderID := uuid.New()
+
+	tests := []struct {
+		name    string
+		to      models.OrderStatus
+		setup   func(*mock_repository.MockorderRepository, *mock_repository.MockorderCache)
+		wantErr error
+	}{
+		{
+			name: "допустимый переход",
+			to:   models.StatusPaid,
+			setup: func(repo *mock_repository.MockorderRepository, c *mock_repository.MockorderCache) {
+				repo.EXPECT().GetOrderById(gomock.Any(), orderID).Return(&models.Order{OrderID: orderID, Status: models.StatusCreated}, nil)
+				repo.EXPECT().UpdateOrderStatus(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, change models.StatusChange) error {
+						assert.Equal(t, models.StatusCreated, change.From)
+						assert.Equal(t, models.StatusPaid, change.To)
+						assert.Equal(t, "operator", change.ChangedBy)
+						return nil
+					})
+				c.EXPECT().Delete(orderID)
+			},
+		},
+		{
+			name: "заказ уже в этом статусе",
+			to:   models.StatusPaid,
+			setup: func(repo *mock_repository.MockorderRepository, _ *mock_repository.MockorderCache) {
+				repo.EXPECT().GetOrderById(gomock.Any(), orderID).Return(&models.Order{OrderID: orderID, Status: models.StatusPaid}, nil)
+			},
+		},
+		{
+			name:    "неизвестный статус",
+			to:      "lost",
+			setup:   func(*mock_repository.MockorderRepository, *mock_repository.MockorderCache) {},
+			wantErr: apperrors.ErrInvalidStatus,
+		},
+		{
+			name: "недопустимый переход",
+			to:   models.StatusShipped,
+			setup: func(repo *mock_repository.MockorderRepository, _ *mock_repository.MockorderCache) {
+				repo.EXPECT().GetOrderById(gomock.Any(), orderID).Return(&models.Order{OrderID: orderID, Status: models.StatusCreated}, nil)
+			},
+			wantErr: apperrors.ErrIllegalStatusTransition,
+		},
+		{
+			name: "заказ не найден",
+			to:   models.StatusPaid,
+			setup: func(repo *mock_repository.MockorderRepository, _ *mock_repository.MockorderCache) {
+				repo.EXPECT().GetOrderById(gomock.Any(), orderID).Return(nil, apperrors.ErrOrderNotFound)
+			},
+			wantErr: apperrors.ErrOrderNotFound,
+		},
+		{
+			name: "статус изменен параллельно",
+			to:   models.StatusPaid,
+			setup: func(repo *mock_repository.MockorderRepository, _ *mock_repository.MockorderCache) {
+				repo.EXPECT().GetOrderById(gomock.Any(), orderID).Return(&models.Order{OrderID: orderID, Status: models.StatusCreated}, nil)
+				repo.EXPECT().UpdateOrderStatus(gomock.Any(), gomock.Any()).Return(apperrors.ErrStatusConflict)
+			},
+			wantErr: apperrors.ErrStatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock_repository.NewMockorderRepository(ctrl)
+			c := mock_repository.NewMockorderCache(ctrl)
+			tt.setup(repo, c)
+
+			change, err := New(c, repo).ChangeStatus(context.Background(), orderID, tt.to, "operator", "оплачен")
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+				assert.Nil(t, change)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.to, change.To)
+		})
+	}
+}
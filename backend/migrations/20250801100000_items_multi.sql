@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE items DROP CONSTRAINT IF EXISTS items_pkey;
+
+ALTER TABLE items ADD COLUMN id BIGSERIAL PRIMARY KEY;
+
+ALTER TABLE items ADD COLUMN position INT NOT NULL DEFAULT 0;
+ALTER TABLE items ALTER COLUMN position DROP DEFAULT;
+
+ALTER TABLE items ADD CONSTRAINT items_order_id_position_key UNIQUE (order_id, position);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM items WHERE position > 0;
+
+ALTER TABLE items DROP CONSTRAINT IF EXISTS items_order_id_position_key;
+
+ALTER TABLE items DROP COLUMN IF EXISTS position;
+
+ALTER TABLE items DROP COLUMN IF EXISTS id;
+
+ALTER TABLE items ADD PRIMARY KEY (order_id);
+
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS orders_date_created_order_uid_idx ON orders (date_created, order_uid);
+
+CREATE INDEX IF NOT EXISTS orders_customer_id_idx ON orders (customer_id);
+
+CREATE INDEX IF NOT EXISTS orders_track_number_idx ON orders (track_number);
+
+CREATE INDEX IF NOT EXISTS payment_amount_order_uid_idx ON payment (amount, order_uid);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS payment_amount_order_uid_idx;
+
+DROP INDEX IF EXISTS orders_track_number_idx;
+
+DROP INDEX IF EXISTS orders_customer_id_idx;
+
+DROP INDEX IF EXISTS orders_date_created_order_uid_idx;
+
+-- +goose StatementEnd